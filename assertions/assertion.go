@@ -0,0 +1,64 @@
+package assertions
+
+import (
+    "fmt"
+    "strings"
+)
+
+// Assertion is a deferred check, returning a message describing the failure or
+// the empty string if the check passes. User-defined checks implemented as an
+// Assertion can be composed and invoked like the built-in assertions.
+type Assertion func() string
+
+// Not creates an Assertion which fails if a passes.
+func Not(a Assertion) Assertion {
+    return func() (s string) {
+        if a() == "" {
+            s = "expected assertion to fail; it passed\n"
+        }
+        return
+    }
+}
+
+// All creates an Assertion which passes only if each of assertions passes.
+// With no assertions it passes, as none failed.
+func All(assertions ...Assertion) Assertion {
+    return func() (s string) {
+        failures := collect(assertions)
+        if len(failures) > 0 {
+            s = fmt.Sprintf("expected all assertions to pass; %d of %d failed\n", len(failures), len(assertions))
+            s += strings.Join(failures, "")
+        }
+        return
+    }
+}
+
+// Any creates an Assertion which passes if at least one of assertions passes.
+// With no assertions it fails, as none passed; this usually means the list of
+// alternatives was built wrongly.
+func Any(assertions ...Assertion) Assertion {
+    return func() (s string) {
+        if len(assertions) == 0 {
+            s = "expected any assertion to pass; none were given\n"
+            return
+        }
+        failures := collect(assertions)
+        if len(failures) == len(assertions) {
+            s = fmt.Sprintf("expected any assertion to pass; all %d failed\n", len(assertions))
+            s += strings.Join(failures, "")
+        }
+        return
+    }
+}
+
+// collect runs each assertion, returning the indented message of each failure.
+func collect(assertions []Assertion) (failures []string) {
+    for _, a := range assertions {
+        result := strings.TrimSpace(a())
+        if result == "" {
+            continue
+        }
+        failures = append(failures, "↪ "+strings.ReplaceAll(result, "\n", "\n  ")+"\n")
+    }
+    return
+}
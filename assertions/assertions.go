@@ -5,29 +5,18 @@ import (
     "errors"
     "fmt"
     "reflect"
-    "runtime"
+    "regexp"
     "strings"
-    "path/filepath"
 
     "github.com/google/go-cmp/cmp"
     "github.com/ninepeach/go-test/interfaces"
 )
 
-const depth = 4
-
-func Caller() string {
-    _, file, line, ok := runtime.Caller(depth)
-    if ok {
-        return fmt.Sprintf("%s:%d: ", filepath.Base(file), line)
-    }
-    return "[???]"
-}
-
 // Creates a diff between `a` and `b` using `cmp.Diff`. Falls back to a string comparison if needed.
 func diff[A, B any](a A, b B, opts cmp.Options) (result string) {
     defer func() {
         if r := recover(); r != nil {
-            result = fmt.Sprintf("↪ Assertion | comparison ↷\na: %#v\nb: %#v\n", a, b)
+            result = fmt.Sprintf("↪ Assertion | comparison ↷\na: %s\nb: %s\n", Pretty(a), Pretty(b))
         }
     }()
     result = "↪ Assertion | differential ↷\n" + cmp.Diff(a, b, opts)
@@ -126,18 +115,18 @@ func False(condition bool) (s string) {
     return
 }
 
-func Zero[N interfaces.Number](value N) (s string) {
+func Zero[N interfaces.Number](value N, opts ...PrintOption) (s string) {
     if value != 0 {
         s = "expected value of 0\n"
-        s += fmt.Sprintf("↪value: %v\n", value)
+        s += fmt.Sprintf("↪value: %s\n", Pretty(value, opts...))
     }
     return
 }
 
-func NonZero[N interfaces.Number](value N) (s string) {
+func NonZero[N interfaces.Number](value N, opts ...PrintOption) (s string) {
     if value == 0 {
         s = "expected non-zero value\n"
-        s += fmt.Sprintf("↪value: %v\n", value)
+        s += fmt.Sprintf("↪value: %s\n", Pretty(value, opts...))
     }
     return
 }
@@ -190,7 +179,9 @@ func ErrorAs[E error, Target *E](err error, target Target) (s string) {
         s = "expected non-nil target; got nil\n"
         return
     }
-    if !errors.As(err, target) {
+    // The conversion to any keeps newer versions of the vet errorsas check
+    // quiet; it cannot see that the generic Target is always a *E.
+    if !errors.As(err, any(target)) {
         s = "expected errors.As match\n"
         s += fmt.Sprintf("↪target: %v\n", target)
         s += fmt.Sprintf("↪   got: %v\n", err)
@@ -198,6 +189,31 @@ func ErrorAs[E error, Target *E](err error, target Target) (s string) {
     return
 }
 
+func Panics(f func()) (s string) {
+    defer func() {
+        if r := recover(); r == nil {
+            s = "expected function to panic; it did not\n"
+        }
+    }()
+    f()
+    return
+}
+
+func PanicsWith(exp any, f func(), opts ...cmp.Option) (s string) {
+    defer func() {
+        switch r := recover(); {
+        case r == nil:
+            s = "expected function to panic; it did not\n"
+        case !equal(exp, r, opts):
+            s = "expected function to panic with different value\n"
+            s += fmt.Sprintf("↪    panic: %s\n", Pretty(r))
+            s += fmt.Sprintf("↪ expected: %s\n", Pretty(exp))
+        }
+    }()
+    f()
+    return
+}
+
 func NoError(err error) (s string) {
     if err != nil {
         s = "expected nil error\n"
@@ -421,26 +437,26 @@ func SliceLen[A any](n int, slice []A) (s string) {
     return
 }
 
-func SliceContainsOp[C comparable](slice []C, item C) (s string) {
+func SliceContainsOp[C comparable](slice []C, item C, opts ...PrintOption) (s string) {
     if !contains(slice, item) {
         s = "expected slice to contain missing item via == operator\n"
-        s += fmt.Sprintf("↪slice is missing %#v\n", item)
+        s += fmt.Sprintf("↪slice is missing %s\n", Pretty(item, opts...))
     }
     return
 }
 
-func SliceContainsFunc[A, B any](slice []A, item B, eq func(a A, b B) bool) (s string) {
+func SliceContainsFunc[A, B any](slice []A, item B, eq func(a A, b B) bool, opts ...PrintOption) (s string) {
     if !containsFunc(slice, item, eq) {
         s = "expected slice to contain missing item via 'eq' function\n"
-        s += fmt.Sprintf("↪slice is missing %#v\n", item)
+        s += fmt.Sprintf("↪slice is missing %s\n", Pretty(item, opts...))
     }
     return
 }
 
-func SliceContainsEqual[E interfaces.EqualFunc[E]](slice []E, item E) (s string) {
+func SliceContainsEqual[E interfaces.EqualFunc[E]](slice []E, item E, opts ...PrintOption) (s string) {
     if !containsFunc(slice, item, E.Equal) {
         s = "expected slice to contain missing item via .Equal method\n"
-        s += fmt.Sprintf("↪slice is missing %#v\n", item)
+        s += fmt.Sprintf("↪slice is missing %s\n", Pretty(item, opts...))
     }
     return
 }
@@ -452,7 +468,7 @@ func SliceContains[A any](slice []A, item A, opts ...cmp.Option) (s string) {
         }
     }
     s = "expected slice to contain missing item via cmp.Equal method\n"
-    s += fmt.Sprintf("↪slice is missing %#v\n", item)
+    s += fmt.Sprintf("↪slice is missing %s\n", Pretty(item))
     return
 }
 
@@ -460,7 +476,7 @@ func SliceNotContains[A any](slice []A, item A, opts ...cmp.Option) (s string) {
     for _, i := range slice {
         if cmp.Equal(i, item, opts...) {
             s = "expected slice to not contain item but it does\n"
-            s += fmt.Sprintf("↪unwanted item %#v\n", item)
+            s += fmt.Sprintf("↪unwanted item %s\n", Pretty(item))
             return
         }
     }
@@ -601,23 +617,23 @@ func MapNotEmpty[M ~map[K]V, K comparable, V any](m M) (s string) {
     return
 }
 
-func MapContainsKey[M ~map[K]V, K comparable, V any](m M, key K) (s string) {
+func MapContainsKey[M ~map[K]V, K comparable, V any](m M, key K, opts ...PrintOption) (s string) {
     if _, exists := m[key]; !exists {
         s = "expected map to contain key\n"
-        s += fmt.Sprintf("↪key: %v\n", key)
+        s += fmt.Sprintf("↪key: %s\n", Pretty(key, opts...))
     }
     return
 }
 
-func MapNotContainsKey[M ~map[K]V, K comparable, V any](m M, key K) (s string) {
+func MapNotContainsKey[M ~map[K]V, K comparable, V any](m M, key K, opts ...PrintOption) (s string) {
     if _, exists := m[key]; exists {
         s = "expected map to not contain key\n"
-        s += fmt.Sprintf("↪key: %v\n", key)
+        s += fmt.Sprintf("↪key: %s\n", Pretty(key, opts...))
     }
     return
 }
 
-func MapContainsKeys[M ~map[K]V, K comparable, V any](m M, keys []K) (s string) {
+func MapContainsKeys[M ~map[K]V, K comparable, V any](m M, keys []K, opts ...PrintOption) (s string) {
     var missing []K
     for _, key := range keys {
         if _, exists := m[key]; !exists {
@@ -627,13 +643,13 @@ func MapContainsKeys[M ~map[K]V, K comparable, V any](m M, keys []K) (s string)
     if len(missing) > 0 {
         s = "expected map to contain keys\n"
         for _, key := range missing {
-            s += fmt.Sprintf("↪key: %v\n", key)
+            s += fmt.Sprintf("↪key: %s\n", Pretty(key, opts...))
         }
     }
     return
 }
 
-func MapNotContainsKeys[M ~map[K]V, K comparable, V any](m M, keys []K) (s string) {
+func MapNotContainsKeys[M ~map[K]V, K comparable, V any](m M, keys []K, opts ...PrintOption) (s string) {
     var unwanted []K
     for _, key := range keys {
         if _, exists := m[key]; exists {
@@ -643,13 +659,13 @@ func MapNotContainsKeys[M ~map[K]V, K comparable, V any](m M, keys []K) (s strin
     if len(unwanted) > 0 {
         s = "expected map to not contain keys\n"
         for _, key := range unwanted {
-            s += fmt.Sprintf("↪key: %v\n", key)
+            s += fmt.Sprintf("↪key: %s\n", Pretty(key, opts...))
         }
     }
     return
 }
 
-func mapContains[M ~map[K]V, K comparable, V any](m M, values []V, eq func(V, V) bool) (s string) {
+func mapContains[M ~map[K]V, K comparable, V any](m M, values []V, eq func(V, V) bool, opts []PrintOption) (s string) {
     var missing []V
     for _, wanted := range values {
         found := false
@@ -667,13 +683,13 @@ func mapContains[M ~map[K]V, K comparable, V any](m M, values []V, eq func(V, V)
     if len(missing) > 0 {
         s = "expected map to contain values\n"
         for _, val := range missing {
-            s += fmt.Sprintf("↪val: %v\n", val)
+            s += fmt.Sprintf("↪val: %s\n", Pretty(val, opts...))
         }
     }
     return
 }
 
-func mapNotContains[M ~map[K]V, K comparable, V any](m M, values []V, eq func(V, V) bool) (s string) {
+func mapNotContains[M ~map[K]V, K comparable, V any](m M, values []V, eq func(V, V) bool, opts []PrintOption) (s string) {
     var unexpected []V
     for _, target := range values {
         found := false
@@ -690,7 +706,7 @@ func mapNotContains[M ~map[K]V, K comparable, V any](m M, values []V, eq func(V,
     if len(unexpected) > 0 {
         s = "expected map to not contain values\n"
         for _, val := range unexpected {
-            s += fmt.Sprintf("↪val: %v\n", val)
+            s += fmt.Sprintf("↪val: %s\n", Pretty(val, opts...))
         }
     }
     return
@@ -699,65 +715,65 @@ func mapNotContains[M ~map[K]V, K comparable, V any](m M, values []V, eq func(V,
 func MapContainsValues[M ~map[K]V, K comparable, V any](m M, vals []V, opts cmp.Options) (s string) {
     return mapContains(m, vals, func(a, b V) bool {
         return equal(a, b, opts)
-    })
+    }, nil)
 }
 
 func MapNotContainsValues[M ~map[K]V, K comparable, V any](m M, vals []V, opts cmp.Options) (s string) {
     return mapNotContains(m, vals, func(a, b V) bool {
         return equal(a, b, opts)
-    })
+    }, nil)
 }
 
-func MapContainsValuesFunc[M ~map[K]V, K comparable, V any](m M, vals []V, eq func(V, V) bool) (s string) {
-    return mapContains(m, vals, eq)
+func MapContainsValuesFunc[M ~map[K]V, K comparable, V any](m M, vals []V, eq func(V, V) bool, opts ...PrintOption) (s string) {
+    return mapContains(m, vals, eq, opts)
 }
 
-func MapNotContainsValuesFunc[M ~map[K]V, K comparable, V any](m M, vals []V, eq func(V, V) bool) (s string) {
-    return mapNotContains(m, vals, eq)
+func MapNotContainsValuesFunc[M ~map[K]V, K comparable, V any](m M, vals []V, eq func(V, V) bool, opts ...PrintOption) (s string) {
+    return mapNotContains(m, vals, eq, opts)
 }
 
-func MapContainsValuesEqual[M ~map[K]V, K comparable, V interfaces.EqualFunc[V]](m M, vals []V) (s string) {
+func MapContainsValuesEqual[M ~map[K]V, K comparable, V interfaces.EqualFunc[V]](m M, vals []V, opts ...PrintOption) (s string) {
     return mapContains(m, vals, func(a, b V) bool {
         return a.Equal(b)
-    })
+    }, opts)
 }
 
-func MapNotContainsValuesEqual[M ~map[K]V, K comparable, V interfaces.EqualFunc[V]](m M, vals []V) (s string) {
+func MapNotContainsValuesEqual[M ~map[K]V, K comparable, V interfaces.EqualFunc[V]](m M, vals []V, opts ...PrintOption) (s string) {
     return mapNotContains(m, vals, func(a, b V) bool {
         return a.Equal(b)
-    })
+    }, opts)
 }
 
 func MapContainsValue[M ~map[K]V, K comparable, V any](m M, val V, opts cmp.Options) (s string) {
     return mapContains(m, []V{val}, func(a, b V) bool {
         return equal(a, b, opts)
-    })
+    }, nil)
 }
 
 func MapNotContainsValue[M ~map[K]V, K comparable, V any](m M, val V, opts cmp.Options) (s string) {
     return mapNotContains(m, []V{val}, func(a, b V) bool {
         return equal(a, b, opts)
-    })
+    }, nil)
 }
 
-func MapContainsValueFunc[M ~map[K]V, K comparable, V any](m M, val V, eq func(V, V) bool) (s string) {
-    return mapContains(m, []V{val}, eq)
+func MapContainsValueFunc[M ~map[K]V, K comparable, V any](m M, val V, eq func(V, V) bool, opts ...PrintOption) (s string) {
+    return mapContains(m, []V{val}, eq, opts)
 }
 
-func MapNotContainsValueFunc[M ~map[K]V, K comparable, V any](m M, val V, eq func(V, V) bool) (s string) {
-    return mapNotContains(m, []V{val}, eq)
+func MapNotContainsValueFunc[M ~map[K]V, K comparable, V any](m M, val V, eq func(V, V) bool, opts ...PrintOption) (s string) {
+    return mapNotContains(m, []V{val}, eq, opts)
 }
 
-func MapContainsValueEqual[M ~map[K]V, K comparable, V interfaces.EqualFunc[V]](m M, val V) (s string) {
+func MapContainsValueEqual[M ~map[K]V, K comparable, V interfaces.EqualFunc[V]](m M, val V, opts ...PrintOption) (s string) {
     return mapContains(m, []V{val}, func(a, b V) bool {
         return a.Equal(b)
-    })
+    }, opts)
 }
 
-func MapNotContainsValueEqual[M ~map[K]V, K comparable, V interfaces.EqualFunc[V]](m M, val V) (s string) {
+func MapNotContainsValueEqual[M ~map[K]V, K comparable, V interfaces.EqualFunc[V]](m M, val V, opts ...PrintOption) (s string) {
     return mapNotContains(m, []V{val}, func(a, b V) bool {
         return a.Equal(b)
-    })
+    }, opts)
 }
 
 func Length(n int, length interfaces.LengthFunc) (s string) {
@@ -799,12 +815,12 @@ func Contains[C any](i C, c interfaces.ContainsFunc[C]) (s string) {
     return
 }
 
-func ContainsSubset[C any](elements []C, container interfaces.ContainsFunc[C]) (s string) {
+func ContainsSubset[C any](elements []C, container interfaces.ContainsFunc[C], opts ...PrintOption) (s string) {
     for i := 0; i < len(elements); i++ {
         element := elements[i]
         if !container.Contains(element) {
             s = "expected to contain element, but does not\n"
-            s += fmt.Sprintf("↪ element: %v\n", element)
+            s += fmt.Sprintf("↪ element: %s\n", Pretty(element, opts...))
             return
         }
     }
@@ -816,4 +832,22 @@ func NotContains[C any](i C, c interfaces.ContainsFunc[C]) (s string) {
         s = "expected not to contain element, but it does\n"
     }
     return
-}
\ No newline at end of file
+}
+
+func That[A any](val A, m interfaces.Matcher[A], opts ...PrintOption) (s string) {
+    if !m.Match(val) {
+        s = "expected value to match\n"
+        s += fmt.Sprintf("↪ matcher: %s\n", m.Describe())
+        s += fmt.Sprintf("↪   value: %s\n", Pretty(val, opts...))
+    }
+    return
+}
+
+func RegexMatch(re *regexp.Regexp, s string) (msg string) {
+    if !re.MatchString(s) {
+        msg = "expected string to match regex\n"
+        msg += fmt.Sprintf("↪ regex: %s\n", re)
+        msg += fmt.Sprintf("↪   val: %q\n", s)
+    }
+    return
+}
@@ -0,0 +1,55 @@
+package assertions
+
+import (
+    "bytes"
+    "encoding/hex"
+    "fmt"
+    "strings"
+    "unicode"
+)
+
+func BytesEq(exp, val []byte) (s string) {
+    if !bytes.Equal(exp, val) {
+        s = "expected equality of bytes\n"
+        s += fmt.Sprintf("↪ len(exp): %d, len(val): %d\n", len(exp), len(val))
+        s += hexDiff(exp, val)
+    }
+    return
+}
+
+func BytesEqHex(exp string, val []byte) (s string) {
+    exp = strings.Map(func(r rune) rune {
+        if unicode.IsSpace(r) {
+            return -1
+        }
+        return r
+    }, exp)
+    decoded, err := hex.DecodeString(exp)
+    if err != nil {
+        return fmt.Sprintf("failed to decode expected hex: %v\n", err)
+    }
+    if !bytes.Equal(decoded, val) {
+        s = "expected equality of bytes via hex\n"
+        s += fmt.Sprintf("↪ len(exp): %d, len(val): %d\n", len(decoded), len(val))
+        s += hexDiff(decoded, val)
+    }
+    return
+}
+
+func BytesHasPrefix(val, prefix []byte) (s string) {
+    if !bytes.HasPrefix(val, prefix) {
+        s = "expected bytes to have prefix\n"
+        s += fmt.Sprintf("↪ len(prefix): %d, len(val): %d\n", len(prefix), len(val))
+        s += hexDiff(prefix, val[:min(len(val), len(prefix))])
+    }
+    return
+}
+
+func BytesContains(val, sub []byte) (s string) {
+    if !bytes.Contains(val, sub) {
+        s = "expected bytes to contain subslice\n"
+        s += fmt.Sprintf("↪ len(val): %d\n", len(val))
+        s += "↪ sub:\n" + hexWindow(0, sub)
+    }
+    return
+}
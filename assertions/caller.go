@@ -0,0 +1,172 @@
+package assertions
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "runtime"
+    "strings"
+    "sync"
+)
+
+// modulePrefix identifies functions belonging to this module, whose frames are
+// never reported as the caller of an assertion.
+const modulePrefix = "github.com/ninepeach/go-test/"
+
+// maxFrames bounds how deep the call stack is walked.
+const maxFrames = 64
+
+// PathFormat controls how the file of a caller is rendered.
+type PathFormat int
+
+const (
+    // BasePath renders only the file name, e.g. "foo_test.go".
+    BasePath PathFormat = iota
+
+    // FullPath renders the absolute path of the file.
+    FullPath
+
+    // ModulePath renders the path of the file relative to the root of the Go
+    // module containing it, falling back to the absolute path.
+    ModulePath
+)
+
+// moduleRoots caches the module root directory found for a source directory.
+var moduleRoots sync.Map
+
+// Helpers is a set of functions marked as helpers. Frames of helper functions
+// are skipped when reporting the caller of a failed assertion, just like
+// testing.T.Helper does for testing output. Callers keep one set per test, so
+// marks do not outlive it. A nil *Helpers contains no functions.
+type Helpers struct {
+    names sync.Map
+}
+
+// Mark marks the first function on the call stack outside of this module as a
+// helper.
+func (h *Helpers) Mark() {
+    for _, frame := range frames(2) {
+        if !internal(frame) {
+            h.names.Store(frame.Function, struct{}{})
+            return
+        }
+    }
+}
+
+func (h *Helpers) contains(function string) bool {
+    if h == nil {
+        return false
+    }
+    _, ok := h.names.Load(function)
+    return ok
+}
+
+// Caller returns the file name and line of the code which invoked an assertion.
+func Caller() string {
+    return CallerFormat(BasePath, nil)
+}
+
+// CallerFormat returns the file and line of the code which invoked an
+// assertion, with the file rendered according to format and the frames of
+// helpers skipped.
+func CallerFormat(format PathFormat, helpers *Helpers) string {
+    for _, frame := range frames(2) {
+        if skip(frame, helpers) {
+            continue
+        }
+        return fmt.Sprintf("%s:%d: ", path(frame.File, format), frame.Line)
+    }
+    return "[???]"
+}
+
+// Stack returns the call stack leading to the invocation of an assertion,
+// starting at the caller and ending before the testing framework.
+func Stack(format PathFormat, helpers *Helpers) (s string) {
+    started := false
+    for _, frame := range frames(2) {
+        if !started && skip(frame, helpers) {
+            continue
+        }
+        if framework(frame) {
+            break
+        }
+        started = true
+        s += fmt.Sprintf("%s\n\t%s:%d\n", frame.Function, path(frame.File, format), frame.Line)
+    }
+    return
+}
+
+// frames returns the frames of the call stack, starting skip frames above the
+// caller of frames.
+func frames(skip int) []runtime.Frame {
+    pcs := make([]uintptr, maxFrames)
+    n := runtime.Callers(skip+1, pcs)
+    iter := runtime.CallersFrames(pcs[:n])
+    var result []runtime.Frame
+    for {
+        frame, more := iter.Next()
+        result = append(result, frame)
+        if !more {
+            break
+        }
+    }
+    return result
+}
+
+// internal reports whether frame belongs to the non-test code of this module.
+func internal(frame runtime.Frame) bool {
+    if strings.HasSuffix(frame.File, "_test.go") {
+        return false
+    }
+    return strings.HasPrefix(frame.Function, modulePrefix)
+}
+
+// framework reports whether frame belongs to the testing package or runtime.
+func framework(frame runtime.Frame) bool {
+    return strings.HasPrefix(frame.Function, "testing.") || strings.HasPrefix(frame.Function, "runtime.")
+}
+
+// skip reports whether frame should not be reported as the caller.
+func skip(frame runtime.Frame, helpers *Helpers) bool {
+    return internal(frame) || helpers.contains(frame.Function)
+}
+
+func path(file string, format PathFormat) string {
+    switch format {
+    case FullPath:
+        return file
+    case ModulePath:
+        root := moduleRoot(filepath.Dir(file))
+        if root == "" {
+            return file
+        }
+        if rel, err := filepath.Rel(root, file); err == nil {
+            return rel
+        }
+        return file
+    default:
+        return filepath.Base(file)
+    }
+}
+
+// moduleRoot returns the nearest directory at or above dir containing a go.mod
+// file, or the empty string if there is none.
+func moduleRoot(dir string) string {
+    if root, ok := moduleRoots.Load(dir); ok {
+        return root.(string)
+    }
+    root := ""
+    for current := dir; ; {
+        if _, err := os.Stat(filepath.Join(current, "go.mod")); err == nil {
+            root = current
+            break
+        }
+        parent := filepath.Dir(current)
+        if parent == current {
+            break
+        }
+        current = parent
+    }
+    moduleRoots.Store(dir, root)
+    return root
+}
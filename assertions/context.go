@@ -0,0 +1,82 @@
+package assertions
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/google/go-cmp/cmp"
+)
+
+// contextState describes whether ctx is done, and why.
+func contextState(ctx context.Context) string {
+    err := ctx.Err()
+    if err == nil {
+        return "↪ context: not done\n"
+    }
+    s := fmt.Sprintf("↪ context: %v\n", err)
+    if cause := context.Cause(ctx); cause != nil && cause != err {
+        s += fmt.Sprintf("↪   cause: %v\n", cause)
+    }
+    return s
+}
+
+func ContextCanceled(ctx context.Context) (s string) {
+    if !errors.Is(ctx.Err(), context.Canceled) {
+        s = "expected context to be canceled\n"
+        s += contextState(ctx)
+    }
+    return
+}
+
+func ContextDeadlineExceeded(ctx context.Context) (s string) {
+    if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+        s = "expected context deadline to be exceeded\n"
+        s += contextState(ctx)
+    }
+    return
+}
+
+func ContextNotDone(ctx context.Context) (s string) {
+    if ctx.Err() != nil {
+        s = "expected context to not be done\n"
+        s += contextState(ctx)
+    }
+    return
+}
+
+func ContextHasValue[A any](ctx context.Context, key any, exp A, opts ...cmp.Option) (s string) {
+    v := ctx.Value(key)
+    if v == nil {
+        s = "expected context to have value for key\n"
+        s += fmt.Sprintf("↪ key: %s\n", Pretty(key))
+        return
+    }
+    val, ok := v.(A)
+    if !ok {
+        s = "expected context value of different type\n"
+        s += fmt.Sprintf("↪  key: %s\n", Pretty(key))
+        s += fmt.Sprintf("↪ type: %T, expected: %T\n", v, exp)
+        return
+    }
+    if !equal(exp, val, opts) {
+        s = "expected equality of context value\n"
+        s += fmt.Sprintf("↪ key: %s\n", Pretty(key))
+        s += diff(exp, val, opts)
+    }
+    return
+}
+
+func ContextDeadlineWithin(ctx context.Context, d time.Duration) (s string) {
+    deadline, ok := ctx.Deadline()
+    if !ok {
+        s = "expected context to have a deadline\n"
+        return
+    }
+    if remaining := time.Until(deadline); remaining > d {
+        s = "expected context deadline within duration\n"
+        s += fmt.Sprintf("↪ remaining: %v, within: %v\n", remaining.Round(time.Millisecond), d)
+    }
+    return
+}
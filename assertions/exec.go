@@ -0,0 +1,48 @@
+package assertions
+
+import (
+    "fmt"
+    "strings"
+)
+
+// stderrNote renders stderr as context for a failure about a process.
+func stderrNote(stderr string) (s string) {
+    if stderr = strings.TrimSpace(stderr); stderr != "" {
+        s = fmt.Sprintf("↪ stderr: %q\n", stderr)
+    }
+    return
+}
+
+func ExitCode(exp, code int, stderr string) (s string) {
+    if code != exp {
+        s = "expected different exit code\n"
+        s += fmt.Sprintf("↪ exit code: %d, expected: %d\n", code, exp)
+        s += stderrNote(stderr)
+    }
+    return
+}
+
+func StdoutEq(exp, stdout string) (s string) {
+    if stdout != exp {
+        s = "expected equality of stdout\n"
+        s += diff(exp, stdout, nil)
+    }
+    return
+}
+
+func StderrContains(stderr, sub string) (s string) {
+    if !strings.Contains(stderr, sub) {
+        s = "expected stderr to contain substring\n"
+        s += fmt.Sprintf("↪ substring: %q\n", sub)
+        s += fmt.Sprintf("↪    stderr: %q\n", stderr)
+    }
+    return
+}
+
+func Killed(killed bool, code int) (s string) {
+    if !killed {
+        s = "expected process to be killed\n"
+        s += fmt.Sprintf("↪ exit code: %d\n", code)
+    }
+    return
+}
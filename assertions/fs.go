@@ -0,0 +1,315 @@
+package assertions
+
+import (
+    "errors"
+    "fmt"
+    "io/fs"
+    "os"
+    slashpath "path"
+    "path/filepath"
+    "strings"
+)
+
+// maxNearby is the most directory entries listed when a file is not found.
+const maxNearby = 10
+
+// filesystem abstracts the operations needed by the file assertions over the
+// OS filesystem and an fs.FS.
+type filesystem struct {
+    stat     func(string) (fs.FileInfo, error)
+    lstat    func(string) (fs.FileInfo, error)
+    readFile func(string) ([]byte, error)
+    readDir  func(string) ([]fs.DirEntry, error)
+    readLink func(string) (string, error)
+    join     func(...string) string
+    dir      func(string) string
+}
+
+var osFilesystem = &filesystem{
+    stat:     os.Stat,
+    lstat:    os.Lstat,
+    readFile: os.ReadFile,
+    readDir:  os.ReadDir,
+    readLink: os.Readlink,
+    join:     filepath.Join,
+    dir:      filepath.Dir,
+}
+
+// readLinkFS is implemented by file systems supporting symbolic links, such as
+// fs.ReadLinkFS in newer versions of Go.
+type readLinkFS interface {
+    ReadLink(string) (string, error)
+    Lstat(string) (fs.FileInfo, error)
+}
+
+func fsFilesystem(system fs.FS) *filesystem {
+    f := &filesystem{
+        stat:     func(name string) (fs.FileInfo, error) { return fs.Stat(system, name) },
+        lstat:    func(name string) (fs.FileInfo, error) { return fs.Stat(system, name) },
+        readFile: func(name string) ([]byte, error) { return fs.ReadFile(system, name) },
+        readDir:  func(name string) ([]fs.DirEntry, error) { return fs.ReadDir(system, name) },
+        readLink: func(name string) (string, error) {
+            return "", &fs.PathError{Op: "readlink", Path: name, Err: errors.ErrUnsupported}
+        },
+        join: slashpath.Join,
+        dir:  slashpath.Dir,
+    }
+    if links, ok := system.(readLinkFS); ok {
+        f.lstat = links.Lstat
+        f.readLink = links.ReadLink
+    }
+    return f
+}
+
+// nearby lists the entries of the directory containing name, to help spot a
+// misspelled path.
+func (f *filesystem) nearby(name string) string {
+    dir := f.dir(name)
+    entries, err := f.readDir(dir)
+    if err != nil {
+        return fmt.Sprintf("↪ directory %q: %v\n", dir, err)
+    }
+    if len(entries) == 0 {
+        return fmt.Sprintf("↪ directory %q is empty\n", dir)
+    }
+    names := make([]string, 0, maxNearby)
+    for _, entry := range entries[:min(len(entries), maxNearby)] {
+        n := entry.Name()
+        if entry.IsDir() {
+            n += "/"
+        }
+        names = append(names, n)
+    }
+    s := fmt.Sprintf("↪ entries of %q: %s", dir, strings.Join(names, ", "))
+    if more := len(entries) - len(names); more > 0 {
+        s += fmt.Sprintf(" (and %d more)", more)
+    }
+    return s + "\n"
+}
+
+// info returns the file info of name, or a failure if it cannot be read.
+func (f *filesystem) info(name string, stat func(string) (fs.FileInfo, error)) (fs.FileInfo, string) {
+    info, err := stat(name)
+    switch {
+    case errors.Is(err, fs.ErrNotExist):
+        s := "expected file to exist\n"
+        s += fmt.Sprintf("↪ name: %q\n", name)
+        s += f.nearby(name)
+        return nil, s
+    case err != nil:
+        s := "expected to stat file\n"
+        s += fmt.Sprintf("↪ name: %q\n", name)
+        s += fmt.Sprintf("↪ error: %v\n", err)
+        return nil, s
+    }
+    return info, ""
+}
+
+// content reads the file name, which must not be a directory.
+func (f *filesystem) content(name string) ([]byte, string) {
+    if s := f.fileExists(name); s != "" {
+        return nil, s
+    }
+    b, err := f.readFile(name)
+    if err != nil {
+        s := "expected to read file\n"
+        s += fmt.Sprintf("↪ name: %q\n", name)
+        s += fmt.Sprintf("↪ error: %v\n", err)
+        return nil, s
+    }
+    return b, ""
+}
+
+func (f *filesystem) fileExists(name string) (s string) {
+    info, s := f.info(name, f.stat)
+    if s == "" && info.IsDir() {
+        s = "expected file but found a directory\n"
+        s += fmt.Sprintf("↪ name: %q\n", name)
+    }
+    return
+}
+
+func (f *filesystem) dirExists(name string) (s string) {
+    info, s := f.info(name, f.stat)
+    if s != "" {
+        return strings.Replace(s, "expected file to exist", "expected directory to exist", 1)
+    }
+    if !info.IsDir() {
+        s = "expected directory but found a file\n"
+        s += fmt.Sprintf("↪ name: %q\n", name)
+    }
+    return
+}
+
+func (f *filesystem) fileNotExists(name string) (s string) {
+    info, err := f.lstat(name)
+    switch {
+    case errors.Is(err, fs.ErrNotExist):
+    case err != nil:
+        s = "expected to stat file\n"
+        s += fmt.Sprintf("↪ name: %q\n", name)
+        s += fmt.Sprintf("↪ error: %v\n", err)
+    default:
+        s = "expected file to not exist\n"
+        s += fmt.Sprintf("↪ name: %q\n", name)
+        s += fmt.Sprintf("↪ mode: %s, size: %d\n", info.Mode(), info.Size())
+    }
+    return
+}
+
+func (f *filesystem) fileMode(name string, mode fs.FileMode) (s string) {
+    info, s := f.info(name, f.lstat)
+    if s == "" && info.Mode() != mode {
+        s = "expected different file mode\n"
+        s += fmt.Sprintf("↪ name: %q\n", name)
+        s += fmt.Sprintf("↪ mode: %s, expected: %s\n", info.Mode(), mode)
+    }
+    return
+}
+
+func (f *filesystem) fileSize(name string, size int64) (s string) {
+    info, s := f.info(name, f.stat)
+    if s == "" && info.Size() != size {
+        s = "expected different file size\n"
+        s += fmt.Sprintf("↪ name: %q\n", name)
+        s += fmt.Sprintf("↪ size: %d, expected: %d\n", info.Size(), size)
+    }
+    return
+}
+
+func (f *filesystem) fileContains(name, content string) (s string) {
+    b, s := f.content(name)
+    if s == "" && !strings.Contains(string(b), content) {
+        s = "expected file to contain content\n"
+        s += fmt.Sprintf("↪     name: %q\n", name)
+        s += fmt.Sprintf("↪  content: %q\n", content)
+        s += fmt.Sprintf("↪     file: %q\n", string(b))
+    }
+    return
+}
+
+func (f *filesystem) fileEq(name, content string) (s string) {
+    b, s := f.content(name)
+    if s == "" && string(b) != content {
+        s = "expected equality of file content\n"
+        s += fmt.Sprintf("↪ name: %q\n", name)
+        s += diff(content, string(b), nil)
+    }
+    return
+}
+
+func (f *filesystem) dirContainsFiles(dir string, files []string) (s string) {
+    if s = f.dirExists(dir); s != "" {
+        return
+    }
+    var missing []string
+    for _, file := range files {
+        if _, err := f.stat(f.join(dir, file)); err != nil {
+            missing = append(missing, file)
+        }
+    }
+    if len(missing) > 0 {
+        s = "expected directory to contain files\n"
+        s += fmt.Sprintf("↪ directory: %q\n", dir)
+        s += fmt.Sprintf("↪   missing: %q\n", missing)
+        s += f.nearby(f.join(dir, missing[0]))
+    }
+    return
+}
+
+func (f *filesystem) fileSymlinkTo(link, target string) (s string) {
+    info, s := f.info(link, f.lstat)
+    if s != "" {
+        return
+    }
+    if info.Mode()&fs.ModeSymlink == 0 {
+        s = "expected file to be a symbolic link\n"
+        s += fmt.Sprintf("↪ name: %q\n", link)
+        s += fmt.Sprintf("↪ mode: %s\n", info.Mode())
+        return
+    }
+    dest, err := f.readLink(link)
+    switch {
+    case err != nil:
+        s = "expected to read symbolic link\n"
+        s += fmt.Sprintf("↪  name: %q\n", link)
+        s += fmt.Sprintf("↪ error: %v\n", err)
+    case dest != target:
+        s = "expected symbolic link to different target\n"
+        s += fmt.Sprintf("↪   name: %q\n", link)
+        s += fmt.Sprintf("↪ target: %q, expected: %q\n", dest, target)
+    }
+    return
+}
+
+func FileExists(file string) string {
+    return osFilesystem.fileExists(file)
+}
+
+func FileExistsFS(system fs.FS, file string) string {
+    return fsFilesystem(system).fileExists(file)
+}
+
+func DirExists(dir string) string {
+    return osFilesystem.dirExists(dir)
+}
+
+func DirExistsFS(system fs.FS, dir string) string {
+    return fsFilesystem(system).dirExists(dir)
+}
+
+func FileNotExists(file string) string {
+    return osFilesystem.fileNotExists(file)
+}
+
+func FileNotExistsFS(system fs.FS, file string) string {
+    return fsFilesystem(system).fileNotExists(file)
+}
+
+func FileMode(file string, mode fs.FileMode) string {
+    return osFilesystem.fileMode(file, mode)
+}
+
+func FileModeFS(system fs.FS, file string, mode fs.FileMode) string {
+    return fsFilesystem(system).fileMode(file, mode)
+}
+
+func FileSize(file string, size int64) string {
+    return osFilesystem.fileSize(file, size)
+}
+
+func FileSizeFS(system fs.FS, file string, size int64) string {
+    return fsFilesystem(system).fileSize(file, size)
+}
+
+func FileContains(file, content string) string {
+    return osFilesystem.fileContains(file, content)
+}
+
+func FileContainsFS(system fs.FS, file, content string) string {
+    return fsFilesystem(system).fileContains(file, content)
+}
+
+func FileEq(file, content string) string {
+    return osFilesystem.fileEq(file, content)
+}
+
+func FileEqFS(system fs.FS, file, content string) string {
+    return fsFilesystem(system).fileEq(file, content)
+}
+
+func DirContainsFiles(dir string, files []string) string {
+    return osFilesystem.dirContainsFiles(dir, files)
+}
+
+func DirContainsFilesFS(system fs.FS, dir string, files []string) string {
+    return fsFilesystem(system).dirContainsFiles(dir, files)
+}
+
+func FileSymlinkTo(link, target string) string {
+    return osFilesystem.fileSymlinkTo(link, target)
+}
+
+func FileSymlinkToFS(system fs.FS, link, target string) string {
+    return fsFilesystem(system).fileSymlinkTo(link, target)
+}
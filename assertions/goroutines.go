@@ -0,0 +1,108 @@
+package assertions
+
+import (
+    "fmt"
+    "runtime"
+    "runtime/debug"
+    "strings"
+    "time"
+)
+
+// goroutineDump returns the stacks of all goroutines, with frames of the
+// runtime, the testing package and this module left out. Goroutines left with
+// no frames are omitted.
+func goroutineDump() string {
+    buf := make([]byte, 1<<16)
+    for {
+        n := runtime.Stack(buf, true)
+        if n < len(buf) {
+            buf = buf[:n]
+            break
+        }
+        buf = make([]byte, 2*len(buf))
+    }
+    return filterGoroutines(string(buf))
+}
+
+// filterGoroutines filters the frames of each goroutine in dump, formatted as
+// by runtime.Stack.
+func filterGoroutines(dump string) string {
+    var b strings.Builder
+    omitted := 0
+    for _, block := range strings.Split(strings.TrimSpace(dump), "\n\n") {
+        lines := strings.Split(block, "\n")
+        var kept []string
+        for i := 1; i+1 < len(lines); i += 2 {
+            function := strings.TrimPrefix(lines[i], "created by ")
+            if end := strings.Index(function, " in goroutine "); end >= 0 {
+                function = function[:end]
+            }
+            if end := strings.LastIndex(function, "("); end > 0 {
+                function = function[:end]
+            }
+            file := strings.TrimSpace(lines[i+1])
+            if end := strings.Index(file, " +0x"); end >= 0 {
+                file = file[:end]
+            }
+            if end := strings.LastIndex(file, ":"); end >= 0 {
+                file = file[:end]
+            }
+            frame := runtime.Frame{Function: function, File: file}
+            if framework(frame) || internal(frame) || harness(frame) {
+                continue
+            }
+            kept = append(kept, lines[i], lines[i+1])
+        }
+        if len(kept) == 0 {
+            omitted++
+            continue
+        }
+        b.WriteString(lines[0] + "\n" + strings.Join(kept, "\n") + "\n\n")
+    }
+    if omitted > 0 {
+        fmt.Fprintf(&b, "(%d goroutine(s) of the runtime and testing packages omitted)\n", omitted)
+    }
+    return b.String()
+}
+
+// harness reports whether frame belongs to the generated test main, to the
+// internal packages of the standard library, or to the runtime implementation
+// of the sync package, such as sync.runtime_SemacquireMutex before Go 1.24.
+func harness(frame runtime.Frame) bool {
+    switch {
+    case strings.HasSuffix(frame.File, "_testmain.go"):
+        return true
+    case strings.HasPrefix(frame.Function, "internal/"):
+        return true
+    case strings.HasPrefix(frame.Function, "sync.runtime_"):
+        return true
+    }
+    return false
+}
+
+func CompletesWithin(d time.Duration, fn func()) (s string) {
+    done := make(chan struct{})
+    var panicked string
+    go func() {
+        defer close(done)
+        defer func() {
+            if r := recover(); r != nil {
+                panicked = "expected function to complete; it panicked\n"
+                panicked += fmt.Sprintf("↪ panic: %v\n", r)
+                panicked += "↪ stack ↷\n" + string(debug.Stack())
+            }
+        }()
+        fn()
+    }()
+    timer := time.NewTimer(d)
+    defer timer.Stop()
+    select {
+    case <-done:
+        s = panicked
+    case <-timer.C:
+        s = fmt.Sprintf("expected function to complete within %v\n", d)
+        s += "↪ goroutines ↷\n"
+        s += goroutineDump()
+    }
+    return
+}
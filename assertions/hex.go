@@ -0,0 +1,141 @@
+package assertions
+
+import (
+    "fmt"
+    "strings"
+)
+
+// bytesPerLine is the number of bytes rendered on each line of a hex dump.
+const bytesPerLine = 16
+
+// printable returns b as a character, or '.' if it is not printable ASCII.
+func printable(b byte) byte {
+    if b < 32 || b > 126 {
+        return '.'
+    }
+    return b
+}
+
+// hexColumns renders the bytes of data as hex columns of a fixed width.
+func hexColumns(data []byte) string {
+    var b strings.Builder
+    for i := 0; i < bytesPerLine; i++ {
+        if i == bytesPerLine/2 {
+            b.WriteByte(' ')
+        }
+        if i < len(data) {
+            fmt.Fprintf(&b, "%02x ", data[i])
+        } else {
+            b.WriteString("   ")
+        }
+    }
+    return b.String()
+}
+
+// hexLine renders up to bytesPerLine bytes starting at offset in the style of
+// hex.Dump, padding short lines so columns stay aligned.
+func hexLine(offset int64, data []byte) string {
+    text := make([]byte, len(data))
+    for i, c := range data {
+        text[i] = printable(c)
+    }
+    return fmt.Sprintf("%08x  %s |%s|", offset, hexColumns(data), text)
+}
+
+// hexWindow renders data, which begins at offset, as hex dump lines.
+func hexWindow(offset int64, data []byte) (s string) {
+    for len(data) > 0 {
+        n := min(len(data), bytesPerLine)
+        s += hexLine(offset, data[:n]) + "\n"
+        data = data[n:]
+        offset += int64(n)
+    }
+    return
+}
+
+// diffContextRows is the number of equal rows shown around each differing row
+// of a hex diff.
+const diffContextRows = 2
+
+// textColumn renders the bytes of data as printable characters padded to a
+// fixed width.
+func textColumn(data []byte) string {
+    var b strings.Builder
+    b.WriteByte('|')
+    for i := 0; i < bytesPerLine; i++ {
+        if i < len(data) {
+            b.WriteByte(printable(data[i]))
+        } else {
+            b.WriteByte(' ')
+        }
+    }
+    b.WriteByte('|')
+    return b.String()
+}
+
+// markers renders a line with ^^ beneath each byte of a row differing between
+// exp and val.
+func markers(exp, val []byte) string {
+    var b strings.Builder
+    for i := 0; i < bytesPerLine; i++ {
+        if i == bytesPerLine/2 {
+            b.WriteByte(' ')
+        }
+        inExp, inVal := i < len(exp), i < len(val)
+        if inExp != inVal || (inExp && exp[i] != val[i]) {
+            b.WriteString("^^ ")
+        } else {
+            b.WriteString("   ")
+        }
+    }
+    return b.String()
+}
+
+func row(data []byte, r int) []byte {
+    start := r * bytesPerLine
+    if start >= len(data) {
+        return nil
+    }
+    return data[start:min(len(data), start+bytesPerLine)]
+}
+
+// hexDiff renders exp and val side by side as hex dumps with aligned offsets,
+// marking the bytes which differ. Long stretches of equal rows are elided.
+func hexDiff(exp, val []byte) string {
+    rows := (max(len(exp), len(val)) + bytesPerLine - 1) / bytesPerLine
+    differs := make([]bool, rows)
+    keep := make([]bool, rows)
+    for r := 0; r < rows; r++ {
+        if string(row(exp, r)) != string(row(val, r)) {
+            differs[r] = true
+            for k := max(0, r-diffContextRows); k <= min(rows-1, r+diffContextRows); k++ {
+                keep[k] = true
+            }
+        }
+    }
+
+    blank := strings.Repeat(" ", len(hexColumns(nil)))
+    var b strings.Builder
+    fmt.Fprintf(&b, "%-10s%s %18s  %s\n", "offset", "exp"+blank[3:], "", "val")
+    skipped := 0
+    for r := 0; r < rows; r++ {
+        if !keep[r] {
+            skipped++
+            continue
+        }
+        if skipped > 0 {
+            fmt.Fprintf(&b, "⋮ %d equal row(s) elided\n", skipped)
+            skipped = 0
+        }
+        e, v := row(exp, r), row(val, r)
+        fmt.Fprintf(&b, "%08x  %s %s  %s %s\n", r*bytesPerLine, hexColumns(e), textColumn(e), hexColumns(v), textColumn(v))
+        if differs[r] {
+            line := fmt.Sprintf("%10s%s %18s  %s", "", markers(e, v), "", markers(e, v))
+            b.WriteString(strings.TrimRight(line, " ") + "\n")
+        }
+    }
+    if skipped > 0 {
+        fmt.Fprintf(&b, "⋮ %d equal row(s) elided\n", skipped)
+    }
+    return b.String()
+}
@@ -0,0 +1,74 @@
+package assertions
+
+import (
+    "fmt"
+    "sort"
+)
+
+// Op is an operation of a concurrent history, as checked by Linearizable.
+type Op struct {
+    // Client identifies the goroutine which performed the operation.
+    Client int
+
+    // Input and Output are the argument and result of the operation, as
+    // interpreted by the model.
+    Input  any
+    Output any
+
+    // Call and Return are logical timestamps of when the operation was
+    // invoked and when it returned.
+    Call, Return int64
+}
+
+func (op Op) String() string {
+    return fmt.Sprintf("client %d: %s → %s [%d, %d]", op.Client, Pretty(op.Input), Pretty(op.Output), op.Call, op.Return)
+}
+
+// linearize searches for an order of ops, consistent with their real time
+// order, in which each operation is accepted by step. It backtracks over the
+// candidate operations, so is only practical for short histories.
+func linearize[S any](state S, step func(S, any, any) (S, bool), ops []Op, done []bool, remaining int) bool {
+    if remaining == 0 {
+        return true
+    }
+
+    // an operation may go next only if it was called before every remaining
+    // operation returned
+    horizon := int64(-1)
+    for i, op := range ops {
+        if !done[i] && (horizon < 0 || op.Return < horizon) {
+            horizon = op.Return
+        }
+    }
+    for i, op := range ops {
+        if done[i] || op.Call > horizon {
+            continue
+        }
+        next, ok := step(state, op.Input, op.Output)
+        if !ok {
+            continue
+        }
+        done[i] = true
+        if linearize(next, step, ops, done, remaining-1) {
+            return true
+        }
+        done[i] = false
+    }
+    return false
+}
+
+func Linearizable[S any](init S, step func(state S, input, output any) (S, bool), history []Op) (s string) {
+    ops := append([]Op(nil), history...)
+    sort.SliceStable(ops, func(i, j int) bool {
+        return ops[i].Call < ops[j].Call
+    })
+    if linearize(init, step, ops, make([]bool, len(ops)), len(ops)) {
+        return
+    }
+    s = "expected history to be linearizable\n"
+    s += fmt.Sprintf("↪ operations: %d\n", len(ops))
+    for _, op := range ops {
+        s += fmt.Sprintf("↪ %s\n", op)
+    }
+    return
+}
@@ -0,0 +1,33 @@
+package assertions
+
+import (
+    "fmt"
+    "strings"
+)
+
+// Lines splits output into lines, ignoring a trailing newline.
+func Lines(output string) []string {
+    if output == "" {
+        return nil
+    }
+    return strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+}
+
+func OutputLines(exp []string, output string) (s string) {
+    lines := Lines(output)
+    if !equal(exp, lines, nil) {
+        s = "expected output lines to match\n"
+        s += fmt.Sprintf("↪ lines(exp): %d, lines(output): %d\n", len(exp), len(lines))
+        s += diff(exp, lines, nil)
+    }
+    return
+}
+
+func OutputContains(output, sub string) (s string) {
+    if !strings.Contains(output, sub) {
+        s = "expected output to contain substring\n"
+        s += fmt.Sprintf("↪ substring: %q\n", sub)
+        s += fmt.Sprintf("↪    output: %q\n", output)
+    }
+    return
+}
@@ -0,0 +1,72 @@
+package assertions
+
+import (
+    "fmt"
+    "runtime"
+    "slices"
+    "testing"
+    "time"
+)
+
+// measureRuns is the number of runs allocations are averaged over.
+const measureRuns = 100
+
+func AllocsAtMost(n float64, f func()) (s string) {
+    allocs := testing.AllocsPerRun(measureRuns, f)
+    if allocs > n {
+        s = "expected fewer allocations per run\n"
+        s += fmt.Sprintf("↪ allocations: %v\n", allocs)
+        s += fmt.Sprintf("↪     maximum: %v\n", n)
+    }
+    return
+}
+
+func BytesAllocatedAtMost(n uint64, f func()) (s string) {
+    defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+    // warm up, so one-time initialization is not measured
+    f()
+
+    var before, after runtime.MemStats
+    runtime.ReadMemStats(&before)
+    for i := 0; i < measureRuns; i++ {
+        f()
+    }
+    runtime.ReadMemStats(&after)
+
+    bytes := (after.TotalAlloc - before.TotalAlloc) / measureRuns
+    if bytes > n {
+        s = "expected fewer bytes allocated per run\n"
+        s += fmt.Sprintf("↪   bytes: %d\n", bytes)
+        s += fmt.Sprintf("↪ maximum: %d\n", n)
+    }
+    return
+}
+
+func FasterThan(budget time.Duration, f func(), runs int) (s string) {
+    if runs < 1 {
+        runs = 1
+    }
+
+    // warm up, so one-time initialization is not measured
+    f()
+
+    durations := make([]time.Duration, runs)
+    for i := range durations {
+        start := time.Now()
+        f()
+        durations[i] = time.Since(start)
+    }
+    slices.Sort(durations)
+
+    median := durations[runs/2]
+    if runs%2 == 0 {
+        median = (durations[runs/2-1] + durations[runs/2]) / 2
+    }
+    if median > budget {
+        s = "expected function to complete within budget\n"
+        s += fmt.Sprintf("↪ median: %v (%d runs)\n", median, runs)
+        s += fmt.Sprintf("↪ budget: %v\n", budget)
+    }
+    return
+}
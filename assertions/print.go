@@ -0,0 +1,272 @@
+package assertions
+
+import (
+    "cmp"
+    "fmt"
+    "reflect"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// maxLineWidth is the longest a composite value may render on one line before
+// its elements are placed on their own lines.
+const maxLineWidth = 80
+
+// indent is the indentation of each nesting level of a composite value.
+const indent = "  "
+
+// PrintOption configures how Pretty renders values.
+type PrintOption func(*printer)
+
+// Stringers renders values implementing fmt.Stringer using their String method.
+func Stringers() PrintOption {
+    return func(p *printer) {
+        p.stringers = true
+    }
+}
+
+// Pretty renders v for use in a failure message. Pointers are dereferenced,
+// cycles are detected, map keys are sorted and large composite values are
+// indented over multiple lines. Values implementing fmt.GoStringer are rendered
+// using GoString, and with the Stringers option, values implementing
+// fmt.Stringer are rendered using String.
+func Pretty(v any, opts ...PrintOption) string {
+    p := &printer{visiting: make(map[visit]bool)}
+    for _, opt := range opts {
+        opt(p)
+    }
+    return p.print(reflect.ValueOf(v), 0)
+}
+
+// visit identifies a reference value on the path currently being printed.
+type visit struct {
+    ptr uintptr
+    typ reflect.Type
+    len int
+}
+
+type printer struct {
+    stringers bool
+    visiting  map[visit]bool
+}
+
+var (
+    goStringerType = reflect.TypeFor[fmt.GoStringer]()
+    stringerType   = reflect.TypeFor[fmt.Stringer]()
+)
+
+func (p *printer) print(v reflect.Value, depth int) string {
+    if !v.IsValid() {
+        return "nil"
+    }
+
+    if s, ok := p.stringer(v); ok {
+        return s
+    }
+
+    switch v.Kind() {
+    case reflect.Bool:
+        return strconv.FormatBool(v.Bool())
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        return strconv.FormatInt(v.Int(), 10)
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+        return strconv.FormatUint(v.Uint(), 10)
+    case reflect.Float32, reflect.Float64:
+        return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+    case reflect.Complex64, reflect.Complex128:
+        return strconv.FormatComplex(v.Complex(), 'g', -1, v.Type().Bits())
+    case reflect.String:
+        return strconv.Quote(v.String())
+    case reflect.Interface:
+        if v.IsNil() {
+            return "nil"
+        }
+        return p.print(v.Elem(), depth)
+    case reflect.Pointer:
+        if v.IsNil() {
+            return fmt.Sprintf("(%s)(nil)", v.Type())
+        }
+        key := visit{ptr: v.Pointer(), typ: v.Type()}
+        if p.visiting[key] {
+            return fmt.Sprintf("<cycle %s>", v.Type())
+        }
+        p.visiting[key] = true
+        defer delete(p.visiting, key)
+        return "&" + p.print(v.Elem(), depth)
+    case reflect.Struct:
+        var fields []string
+        for i := 0; i < v.NumField(); i++ {
+            fields = append(fields, v.Type().Field(i).Name+": "+p.print(v.Field(i), depth+1))
+        }
+        return p.composite(v.Type().String(), fields, depth)
+    case reflect.Slice:
+        if v.IsNil() {
+            return fmt.Sprintf("%s(nil)", v.Type())
+        }
+        key := visit{ptr: v.Pointer(), typ: v.Type(), len: v.Len()}
+        if p.visiting[key] {
+            return fmt.Sprintf("<cycle %s>", v.Type())
+        }
+        p.visiting[key] = true
+        defer delete(p.visiting, key)
+        return p.elements(v, depth)
+    case reflect.Array:
+        return p.elements(v, depth)
+    case reflect.Map:
+        if v.IsNil() {
+            return fmt.Sprintf("%s(nil)", v.Type())
+        }
+        key := visit{ptr: v.Pointer(), typ: v.Type()}
+        if p.visiting[key] {
+            return fmt.Sprintf("<cycle %s>", v.Type())
+        }
+        p.visiting[key] = true
+        defer delete(p.visiting, key)
+        return p.entries(v, depth)
+    case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+        if v.IsNil() {
+            return fmt.Sprintf("(%s)(nil)", v.Type())
+        }
+        return fmt.Sprintf("(%s)(%#x)", v.Type(), v.Pointer())
+    default:
+        return v.String()
+    }
+}
+
+// stringer renders v using its GoString method, or its String method if
+// configured.
+func (p *printer) stringer(v reflect.Value) (string, bool) {
+    if !v.CanInterface() {
+        return "", false
+    }
+    if v.Kind() == reflect.Pointer && v.IsNil() {
+        return "", false
+    }
+    switch {
+    case v.Type().Implements(goStringerType):
+        return v.Interface().(fmt.GoStringer).GoString(), true
+    case p.stringers && v.Type().Implements(stringerType):
+        return v.Interface().(fmt.Stringer).String(), true
+    }
+    return "", false
+}
+
+func (p *printer) elements(v reflect.Value, depth int) string {
+    elements := make([]string, v.Len())
+    for i := range elements {
+        elements[i] = p.print(v.Index(i), depth+1)
+    }
+    return p.composite(v.Type().String(), elements, depth)
+}
+
+func (p *printer) entries(v reflect.Value, depth int) string {
+    type entry struct {
+        key      reflect.Value
+        rendered string
+        value    string
+    }
+    entries := make([]entry, 0, v.Len())
+    iter := v.MapRange()
+    for iter.Next() {
+        entries = append(entries, entry{
+            key:      iter.Key(),
+            rendered: p.print(iter.Key(), depth+1),
+            value:    p.print(iter.Value(), depth+1),
+        })
+    }
+    sort.Slice(entries, func(i, j int) bool {
+        if c, ok := compareKeys(entries[i].key, entries[j].key); ok {
+            return c < 0
+        }
+        return entries[i].rendered < entries[j].rendered
+    })
+    items := make([]string, len(entries))
+    for i, e := range entries {
+        items[i] = e.rendered + ": " + e.value
+    }
+    return p.composite(v.Type().String(), items, depth)
+}
+
+// compareKeys orders the map keys a and b of the same type like fmt does:
+// numbers numerically, strings lexically, false before true, pointers and
+// channels by address, and structs and arrays element by element. It reports
+// false if the keys have no such order, as for interface keys holding values
+// of different types.
+func compareKeys(a, b reflect.Value) (int, bool) {
+    if a.Type() != b.Type() {
+        return 0, false
+    }
+    switch a.Kind() {
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        return cmp.Compare(a.Int(), b.Int()), true
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+        return cmp.Compare(a.Uint(), b.Uint()), true
+    case reflect.String:
+        return cmp.Compare(a.String(), b.String()), true
+    case reflect.Float32, reflect.Float64:
+        return cmp.Compare(a.Float(), b.Float()), true
+    case reflect.Complex64, reflect.Complex128:
+        if c := cmp.Compare(real(a.Complex()), real(b.Complex())); c != 0 {
+            return c, true
+        }
+        return cmp.Compare(imag(a.Complex()), imag(b.Complex())), true
+    case reflect.Bool:
+        switch {
+        case a.Bool() == b.Bool():
+            return 0, true
+        case b.Bool():
+            return -1, true
+        default:
+            return 1, true
+        }
+    case reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+        return cmp.Compare(a.Pointer(), b.Pointer()), true
+    case reflect.Struct:
+        for i := 0; i < a.NumField(); i++ {
+            if c, ok := compareKeys(a.Field(i), b.Field(i)); !ok || c != 0 {
+                return c, ok
+            }
+        }
+        return 0, true
+    case reflect.Array:
+        for i := 0; i < a.Len(); i++ {
+            if c, ok := compareKeys(a.Index(i), b.Index(i)); !ok || c != 0 {
+                return c, ok
+            }
+        }
+        return 0, true
+    case reflect.Interface:
+        switch {
+        case a.IsNil() && b.IsNil():
+            return 0, true
+        case a.IsNil():
+            return -1, true
+        case b.IsNil():
+            return 1, true
+        }
+        return compareKeys(a.Elem(), b.Elem())
+    default:
+        return 0, false
+    }
+}
+
+// composite renders items within braces, on one line if they are short enough
+// and otherwise each on its own indented line.
+func (p *printer) composite(typ string, items []string, depth int) string {
+    if len(items) == 0 {
+        return typ + "{}"
+    }
+    line := typ + "{" + strings.Join(items, ", ") + "}"
+    if len(line)+len(indent)*depth <= maxLineWidth && !strings.Contains(line, "\n") {
+        return line
+    }
+    prefix := strings.Repeat(indent, depth+1)
+    var b strings.Builder
+    b.WriteString(typ + "{\n")
+    for _, item := range items {
+        b.WriteString(prefix + item + ",\n")
+    }
+    b.WriteString(strings.Repeat(indent, depth) + "}")
+    return b.String()
+}
@@ -0,0 +1,85 @@
+package assertions
+
+import (
+    "strings"
+    "testing"
+    "time"
+)
+
+type node struct {
+    Name string
+    Next *node
+}
+
+type celsius float64
+
+func (c celsius) String() string {
+    return "hot"
+}
+
+func TestPretty(t *testing.T) {
+    long := strings.Repeat("x", 40)
+    cases := []struct {
+        name string
+        val  any
+        exp  string
+    }{
+        {"nil", nil, "nil"},
+        {"int", 42, "42"},
+        {"string", "a\tb", `"a\tb"`},
+        {"float", 1.5, "1.5"},
+        {"nil pointer", (*node)(nil), "(*assertions.node)(nil)"},
+        {"pointer", &node{Name: "a"}, `&assertions.node{Name: "a", Next: (*assertions.node)(nil)}`},
+        {"nil slice", []int(nil), "[]int(nil)"},
+        {"slice", []int{1, 2, 3}, "[]int{1, 2, 3}"},
+        {"map sorted", map[string]int{"b": 2, "a": 1}, `map[string]int{"a": 1, "b": 2}`},
+        {"map int keys", map[int]string{2: "b", 10: "c", 1: "a", -3: "z"}, `map[int]string{-3: "z", 1: "a", 2: "b", 10: "c"}`},
+        {"map float keys", map[float64]bool{10.5: true, 2: false, 9.25: true}, `map[float64]bool{2: false, 9.25: true, 10.5: true}`},
+        {"map array keys", map[[2]uint]int{{10, 1}: 1, {2, 5}: 2, {2, 3}: 3}, `map[[2]uint]int{[2]uint{2, 3}: 3, [2]uint{2, 5}: 2, [2]uint{10, 1}: 1}`},
+        {"empty struct", struct{}{}, "struct {}{}"},
+        {"stringer ignored", celsius(30), "30"},
+        {
+            "indented",
+            []string{long, long},
+            "[]string{\n  \"" + long + "\",\n  \"" + long + "\",\n}",
+        },
+        {
+            "nested",
+            map[string][]string{"k": {long, long}},
+            "map[string][]string{\n  \"k\": []string{\n    \"" + long + "\",\n    \"" + long + "\",\n  },\n}",
+        },
+    }
+
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            if result := Pretty(tc.val); result != tc.exp {
+                t.Fatalf("expected:\n%s\ngot:\n%s", tc.exp, result)
+            }
+        })
+    }
+}
+
+func TestPretty_cycle(t *testing.T) {
+    n := &node{Name: "a"}
+    n.Next = n
+    exp := `&assertions.node{Name: "a", Next: <cycle *assertions.node>}`
+    if result := Pretty(n); result != exp {
+        t.Fatalf("expected:\n%s\ngot:\n%s", exp, result)
+    }
+}
+
+func TestPretty_stringers(t *testing.T) {
+    if result := Pretty([]celsius{1}); result != "[]assertions.celsius{1}" {
+        t.Fatalf("expected stringer to be ignored by default, got %s", result)
+    }
+    if result := Pretty([]celsius{1}, Stringers()); result != "[]assertions.celsius{hot}" {
+        t.Fatalf("expected stringer output, got %s", result)
+    }
+}
+
+func TestPretty_goStringer(t *testing.T) {
+    date := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+    if result, exp := Pretty(date), date.GoString(); result != exp {
+        t.Fatalf("expected %s, got %s", exp, result)
+    }
+}
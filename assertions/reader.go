@@ -0,0 +1,135 @@
+package assertions
+
+import (
+    "bufio"
+    "bytes"
+    "fmt"
+    "io"
+    "regexp"
+)
+
+// chunkSize is the number of bytes read at a time when streaming a reader.
+const chunkSize = 32 * 1024
+
+// windowSize is the number of bytes shown on each side of the first difference
+// between two readers.
+const windowSize = 16
+
+func ReaderEq(exp, val io.Reader) (s string) {
+    bufExp := make([]byte, chunkSize)
+    bufVal := make([]byte, chunkSize)
+    var previous []byte
+    var offset int64
+
+    for {
+        nExp, errExp := io.ReadFull(exp, bufExp)
+        nVal, errVal := io.ReadFull(val, bufVal)
+        if err := readErr(errExp); err != nil {
+            return fmt.Sprintf("failed to read expected reader: %v\n", err)
+        }
+        if err := readErr(errVal); err != nil {
+            return fmt.Sprintf("failed to read value reader: %v\n", err)
+        }
+
+        n := min(nExp, nVal)
+        i := firstDiff(bufExp[:n], bufVal[:n])
+        if i < 0 && nExp != nVal {
+            i = n
+        }
+        if i >= 0 {
+            at := offset + int64(i)
+            s = "expected equality of readers\n"
+            switch {
+            case i < n:
+                s += fmt.Sprintf("↪ first difference at offset %d (%#x)\n", at, at)
+            case nExp < nVal:
+                s += fmt.Sprintf("↪ expected reader ended at offset %d (%#x)\n", at, at)
+            default:
+                s += fmt.Sprintf("↪ value reader ended at offset %d (%#x)\n", at, at)
+            }
+            before := append(previous, bufExp[:i]...)
+            before = before[max(0, len(before)-windowSize):]
+            start := at - int64(len(before))
+            s += "↪ exp:\n" + hexWindow(start, append(before, bufExp[i:min(nExp, i+windowSize)]...))
+            s += "↪ val:\n" + hexWindow(start, append(before, bufVal[i:min(nVal, i+windowSize)]...))
+            return
+        }
+
+        if nExp < chunkSize {
+            return
+        }
+        offset += int64(n)
+        previous = append(previous[:0], bufExp[n-windowSize:n]...)
+    }
+}
+
+// readErr filters out the errors of io.ReadFull signalling the end of input.
+func readErr(err error) error {
+    if err == io.EOF || err == io.ErrUnexpectedEOF {
+        return nil
+    }
+    return err
+}
+
+// firstDiff returns the index of the first byte differing between a and b,
+// which are the same length, or -1 if they are equal.
+func firstDiff(a, b []byte) int {
+    if bytes.Equal(a, b) {
+        return -1
+    }
+    for i := range a {
+        if a[i] != b[i] {
+            return i
+        }
+    }
+    return -1
+}
+
+func ReaderContains(r io.Reader, sub string) (s string) {
+    target := []byte(sub)
+    buf := make([]byte, 0, chunkSize+len(target))
+    var total int64
+    chunk := make([]byte, chunkSize)
+    for {
+        n, err := r.Read(chunk)
+        total += int64(n)
+        buf = append(buf, chunk[:n]...)
+        if bytes.Contains(buf, target) {
+            return
+        }
+        // keep only the bytes which may begin a match spanning chunks
+        if keep := len(target) - 1; len(buf) > keep {
+            buf = append(buf[:0], buf[len(buf)-keep:]...)
+        }
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return fmt.Sprintf("failed to read reader: %v\n", err)
+        }
+    }
+    s = "expected reader to contain substring\n"
+    s += fmt.Sprintf("↪ substring: %q\n", sub)
+    s += fmt.Sprintf("↪      read: %d bytes\n", total)
+    return
+}
+
+func ReaderMatchesRegex(r io.Reader, re *regexp.Regexp) (s string) {
+    if !re.MatchReader(bufio.NewReaderSize(r, chunkSize)) {
+        s = "expected reader to match regex\n"
+        s += fmt.Sprintf("↪ regex: %s\n", re)
+    }
+    return
+}
+
+func ReaderLen(n int64, r io.Reader) (s string) {
+    l, err := io.Copy(io.Discard, r)
+    if err != nil {
+        return fmt.Sprintf("failed to read reader: %v\n", err)
+    }
+    if l != n {
+        s = "expected reader to be different length\n"
+        s += fmt.Sprintf("↪ len(reader): %d, expected: %d\n", l, n)
+    }
+    return
+}
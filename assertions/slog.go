@@ -0,0 +1,180 @@
+package assertions
+
+import (
+    "fmt"
+    "log/slog"
+    "regexp"
+    "time"
+
+    "github.com/google/go-cmp/cmp"
+)
+
+// LogEntry is a captured log/slog record, with the attributes of the record and
+// of its logger flattened into a single list. Attributes within groups have
+// keys qualified by the group names, separated by dots.
+type LogEntry struct {
+    Time    time.Time
+    Level   slog.Level
+    Message string
+    Attrs   []slog.Attr
+}
+
+func (e LogEntry) String() string {
+    return fmt.Sprintf("%s %q", e.Level, e.Message) + formatAttrs(e.Attrs)
+}
+
+func formatAttrs(attrs []slog.Attr) (s string) {
+    for _, a := range attrs {
+        s += fmt.Sprintf(" %s=%s", a.Key, Pretty(a.Value.Any()))
+    }
+    return
+}
+
+// LogMatch describes the log entries expected by LogOrdered.
+type LogMatch struct {
+    // Level is the exact level of a matching entry.
+    Level slog.Level
+
+    // Message is a regular expression the message of an entry must match.
+    Message string
+
+    // Attrs must each be present in a matching entry, with an equal value.
+    Attrs []slog.Attr
+}
+
+// FlattenAttrs resolves attrs and flattens any groups, qualifying the keys of
+// their members with prefix and the group key.
+func FlattenAttrs(prefix string, attrs []slog.Attr) (flat []slog.Attr) {
+    for _, a := range attrs {
+        a.Value = a.Value.Resolve()
+        if a.Equal(slog.Attr{}) {
+            continue
+        }
+        if a.Value.Kind() == slog.KindGroup {
+            p := prefix
+            if a.Key != "" {
+                p += a.Key + "."
+            }
+            flat = append(flat, FlattenAttrs(p, a.Value.Group())...)
+            continue
+        }
+        a.Key = prefix + a.Key
+        flat = append(flat, a)
+    }
+    return
+}
+
+// matcher compiles the criteria of m into a function matching entries.
+func (m LogMatch) matcher(opts cmp.Options) (func(LogEntry) bool, error) {
+    re, err := regexp.Compile(m.Message)
+    if err != nil {
+        return nil, err
+    }
+    want := FlattenAttrs("", m.Attrs)
+    return func(e LogEntry) bool {
+        if e.Level != m.Level || !re.MatchString(e.Message) {
+            return false
+        }
+        for _, w := range want {
+            found := false
+            for _, a := range e.Attrs {
+                if a.Key == w.Key && equal(w.Value.Any(), a.Value.Any(), opts) {
+                    found = true
+                    break
+                }
+            }
+            if !found {
+                return false
+            }
+        }
+        return true
+    }, nil
+}
+
+func (m LogMatch) String() string {
+    return fmt.Sprintf("%s /%s/", m.Level, m.Message) + formatAttrs(FlattenAttrs("", m.Attrs))
+}
+
+func captured(entries []LogEntry) (s string) {
+    s = fmt.Sprintf("↪ captured %d entries:\n", len(entries))
+    for _, e := range entries {
+        s += "  " + e.String() + "\n"
+    }
+    return
+}
+
+func count(entries []LogEntry, m LogMatch, opts cmp.Options) (int, error) {
+    match, err := m.matcher(opts)
+    if err != nil {
+        return 0, err
+    }
+    n := 0
+    for _, e := range entries {
+        if match(e) {
+            n++
+        }
+    }
+    return n, nil
+}
+
+func LogContains(entries []LogEntry, m LogMatch, opts cmp.Options) (s string) {
+    n, err := count(entries, m, opts)
+    if err != nil {
+        return fmt.Sprintf("invalid message regex: %v\n", err)
+    }
+    if n == 0 {
+        s = "expected log entry matching\n"
+        s += "↪ match: " + m.String() + "\n"
+        s += captured(entries)
+    }
+    return
+}
+
+func LogNotContains(entries []LogEntry, m LogMatch, opts cmp.Options) (s string) {
+    n, err := count(entries, m, opts)
+    if err != nil {
+        return fmt.Sprintf("invalid message regex: %v\n", err)
+    }
+    if n > 0 {
+        s = "expected no log entry matching\n"
+        s += "↪ match: " + m.String() + "\n"
+        s += captured(entries)
+    }
+    return
+}
+
+func LogCount(n int, entries []LogEntry, m LogMatch, opts cmp.Options) (s string) {
+    c, err := count(entries, m, opts)
+    if err != nil {
+        return fmt.Sprintf("invalid message regex: %v\n", err)
+    }
+    if c != n {
+        s = "expected different number of log entries matching\n"
+        s += "↪ match: " + m.String() + "\n"
+        s += fmt.Sprintf("↪ count: %d, expected: %d\n", c, n)
+        s += captured(entries)
+    }
+    return
+}
+
+func LogOrdered(entries []LogEntry, matches []LogMatch, opts cmp.Options) (s string) {
+    next := 0
+    for _, e := range entries {
+        if next == len(matches) {
+            break
+        }
+        match, err := matches[next].matcher(opts)
+        if err != nil {
+            return fmt.Sprintf("invalid message regex: %v\n", err)
+        }
+        if match(e) {
+            next++
+        }
+    }
+    if next < len(matches) {
+        s = "expected log entries matching in order\n"
+        s += fmt.Sprintf("↪ matched %d of %d; missing: %s\n", next, len(matches), matches[next])
+        s += captured(entries)
+    }
+    return
+}
@@ -0,0 +1,162 @@
+package assertions
+
+import (
+    "fmt"
+    "io/fs"
+    slashpath "path"
+    "sort"
+    "strings"
+    "unicode/utf8"
+)
+
+// TreeOptions configures how DirTreeEq compares two directory trees.
+type TreeOptions struct {
+    // Ignore holds path.Match patterns of paths to leave out of the
+    // comparison. A pattern matches either the slash separated path relative
+    // to the root of the tree or its base name. Ignored directories are not
+    // descended into.
+    Ignore []string
+
+    // NormalizeLineEndings treats "\r\n" in file content as "\n".
+    NormalizeLineEndings bool
+
+    // IgnoreModes leaves the permissions of files and directories out of the
+    // comparison.
+    IgnoreModes bool
+}
+
+// treeEntry is a file or directory of a tree.
+type treeEntry struct {
+    dir  bool
+    mode fs.FileMode
+}
+
+func (o TreeOptions) ignored(name string) bool {
+    for _, pattern := range o.Ignore {
+        if ok, _ := slashpath.Match(pattern, name); ok {
+            return true
+        }
+        if ok, _ := slashpath.Match(pattern, slashpath.Base(name)); ok {
+            return true
+        }
+    }
+    return false
+}
+
+// walkTree lists the entries of system which are not ignored.
+func walkTree(system fs.FS, o TreeOptions) (map[string]treeEntry, error) {
+    entries := make(map[string]treeEntry)
+    err := fs.WalkDir(system, ".", func(name string, d fs.DirEntry, err error) error {
+        if err != nil {
+            return err
+        }
+        if name == "." {
+            return nil
+        }
+        if o.ignored(name) {
+            if d.IsDir() {
+                return fs.SkipDir
+            }
+            return nil
+        }
+        info, err := d.Info()
+        if err != nil {
+            return err
+        }
+        entries[name] = treeEntry{dir: d.IsDir(), mode: info.Mode()}
+        return nil
+    })
+    return entries, err
+}
+
+// treeContent reads name from system, normalizing line endings if configured.
+func treeContent(system fs.FS, name string, o TreeOptions) (string, error) {
+    b, err := fs.ReadFile(system, name)
+    s := string(b)
+    if o.NormalizeLineEndings {
+        s = strings.ReplaceAll(s, "\r\n", "\n")
+    }
+    return s, err
+}
+
+func DirTreeEq(exp, val fs.FS, o TreeOptions) (s string) {
+    expEntries, err := walkTree(exp, o)
+    if err != nil {
+        s = "expected to read expected directory tree\n"
+        s += fmt.Sprintf("↪ error: %v\n", err)
+        return
+    }
+    valEntries, err := walkTree(val, o)
+    if err != nil {
+        s = "expected to read directory tree\n"
+        s += fmt.Sprintf("↪ error: %v\n", err)
+        return
+    }
+
+    names := make([]string, 0, len(expEntries)+len(valEntries))
+    for name := range expEntries {
+        names = append(names, name)
+    }
+    for name := range valEntries {
+        if _, exists := expEntries[name]; !exists {
+            names = append(names, name)
+        }
+    }
+    sort.Strings(names)
+
+    var missing, extra []string
+    var details strings.Builder
+    for _, name := range names {
+        e, inExp := expEntries[name]
+        v, inVal := valEntries[name]
+        switch {
+        case !inVal:
+            missing = append(missing, name)
+        case !inExp:
+            extra = append(extra, name)
+        case e.dir != v.dir:
+            details.WriteString(fmt.Sprintf("↪ type of %q: %s, expected: %s\n", name, v.mode.Type(), e.mode.Type()))
+        default:
+            if !o.IgnoreModes && e.mode.Perm() != v.mode.Perm() {
+                details.WriteString(fmt.Sprintf("↪ mode of %q: %s, expected: %s\n", name, v.mode, e.mode))
+            }
+            if !e.dir {
+                details.WriteString(treeFileDiff(exp, val, name, o))
+            }
+        }
+    }
+
+    if len(missing) == 0 && len(extra) == 0 && details.Len() == 0 {
+        return
+    }
+    s = "expected equality of directory trees\n"
+    if len(missing) > 0 {
+        s += fmt.Sprintf("↪ missing: %s\n", strings.Join(missing, ", "))
+    }
+    if len(extra) > 0 {
+        s += fmt.Sprintf("↪   extra: %s\n", strings.Join(extra, ", "))
+    }
+    s += details.String()
+    return
+}
+
+// treeFileDiff compares the content of name in exp and val, rendering a text
+// diff, or a hex diff for binary content.
+func treeFileDiff(exp, val fs.FS, name string, o TreeOptions) string {
+    e, err := treeContent(exp, name, o)
+    if err != nil {
+        return fmt.Sprintf("↪ read expected %q: %v\n", name, err)
+    }
+    v, err := treeContent(val, name, o)
+    if err != nil {
+        return fmt.Sprintf("↪ read %q: %v\n", name, err)
+    }
+    if e == v {
+        return ""
+    }
+    s := fmt.Sprintf("↪ content of %q differs ↷\n", name)
+    if utf8.ValidString(e) && utf8.ValidString(v) {
+        return s + strings.TrimPrefix(diff(e, v, nil), "↪ Assertion | differential ↷\n")
+    }
+    return s + hexDiff([]byte(e), []byte(v))
+}
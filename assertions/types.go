@@ -0,0 +1,208 @@
+package assertions
+
+import (
+    "fmt"
+    "reflect"
+    "strings"
+)
+
+// typeName renders typ with the full package path of each named type, such as
+// "*github.com/org/repo/plugin.Handler" rather than "*plugin.Handler".
+func typeName(typ reflect.Type) string {
+    if typ == nil {
+        return "<nil>"
+    }
+    if typ.Name() != "" {
+        if typ.PkgPath() == "" {
+            return typ.String()
+        }
+        return typ.PkgPath() + "." + typ.Name()
+    }
+    switch typ.Kind() {
+    case reflect.Pointer:
+        return "*" + typeName(typ.Elem())
+    case reflect.Slice:
+        return "[]" + typeName(typ.Elem())
+    case reflect.Array:
+        return fmt.Sprintf("[%d]%s", typ.Len(), typeName(typ.Elem()))
+    case reflect.Map:
+        return "map[" + typeName(typ.Key()) + "]" + typeName(typ.Elem())
+    case reflect.Chan:
+        switch typ.ChanDir() {
+        case reflect.RecvDir:
+            return "<-chan " + typeName(typ.Elem())
+        case reflect.SendDir:
+            return "chan<- " + typeName(typ.Elem())
+        default:
+            return "chan " + typeName(typ.Elem())
+        }
+    case reflect.Func:
+        return "func" + signature(typ)
+    case reflect.Struct:
+        if typ.NumField() == 0 {
+            return "struct {}"
+        }
+        fields := make([]string, typ.NumField())
+        for i := range fields {
+            f := typ.Field(i)
+            field := typeName(f.Type)
+            if !f.Anonymous {
+                field = f.Name + " " + field
+            }
+            if f.Tag != "" {
+                field += fmt.Sprintf(" %q", string(f.Tag))
+            }
+            fields[i] = field
+        }
+        return "struct { " + strings.Join(fields, "; ") + " }"
+    case reflect.Interface:
+        if typ.NumMethod() == 0 {
+            return "interface {}"
+        }
+        methods := make([]string, typ.NumMethod())
+        for i := range methods {
+            m := typ.Method(i)
+            methods[i] = m.Name + signature(m.Type)
+        }
+        return "interface { " + strings.Join(methods, "; ") + " }"
+    default:
+        return typ.String()
+    }
+}
+
+// signature renders the parameters and results of the func type typ, such as
+// "([]uint8) (int, error)".
+func signature(typ reflect.Type) string {
+    in := make([]string, typ.NumIn())
+    for i := range in {
+        if typ.IsVariadic() && i == len(in)-1 {
+            in[i] = "..." + typeName(typ.In(i).Elem())
+            continue
+        }
+        in[i] = typeName(typ.In(i))
+    }
+    out := make([]string, typ.NumOut())
+    for i := range out {
+        out[i] = typeName(typ.Out(i))
+    }
+    s := "(" + strings.Join(in, ", ") + ")"
+    switch len(out) {
+    case 0:
+    case 1:
+        s += " " + out[0]
+    default:
+        s += " (" + strings.Join(out, ", ") + ")"
+    }
+    return s
+}
+
+func IsType[A any](v any) (s string) {
+    if _, ok := v.(A); !ok {
+        s = "expected value of different type\n"
+        s += fmt.Sprintf("↪     type: %s\n", typeName(reflect.TypeOf(v)))
+        s += fmt.Sprintf("↪ expected: %s\n", typeName(reflect.TypeFor[A]()))
+    }
+    return
+}
+
+func Implements[I any](v any) (s string) {
+    iface := reflect.TypeFor[I]()
+    switch {
+    case iface.Kind() != reflect.Interface:
+        s = "expected an interface type to check against\n"
+        s += fmt.Sprintf("↪ type: %s\n", typeName(iface))
+    case v == nil:
+        s = "expected value to implement interface\n"
+        s += "↪      value: nil\n"
+        s += fmt.Sprintf("↪ interface: %s\n", typeName(iface))
+    case !reflect.TypeOf(v).Implements(iface):
+        s = "expected value to implement interface\n"
+        s += fmt.Sprintf("↪      type: %s\n", typeName(reflect.TypeOf(v)))
+        s += fmt.Sprintf("↪ interface: %s\n", typeName(iface))
+        s += missingMethods(reflect.TypeOf(v), iface)
+    }
+    return
+}
+
+// missingMethods lists the methods of iface which typ does not have, has with
+// a different signature, or has only on a pointer receiver.
+func missingMethods(typ, iface reflect.Type) (s string) {
+    for i := 0; i < iface.NumMethod(); i++ {
+        m := iface.Method(i)
+        exp := m.Name + signature(m.Type)
+        method, ok := typ.MethodByName(m.Name)
+        switch {
+        case ok && methodType(typ, method) != m.Type:
+            s += fmt.Sprintf("↪     wrong: %s%s, expected: %s\n", m.Name, signature(methodType(typ, method)), exp)
+        case ok:
+        case typ.Kind() != reflect.Pointer && typ.Kind() != reflect.Interface && hasMethod(reflect.PointerTo(typ), m):
+            s += fmt.Sprintf("↪   missing: %s (method has pointer receiver)\n", exp)
+        default:
+            s += fmt.Sprintf("↪   missing: %s\n", exp)
+        }
+    }
+    return
+}
+
+// methodType returns the type of method without its receiver, comparable with
+// the method types of an interface.
+func methodType(typ reflect.Type, method reflect.Method) reflect.Type {
+    if typ.Kind() == reflect.Interface {
+        return method.Type
+    }
+    in := make([]reflect.Type, method.Type.NumIn()-1)
+    for i := range in {
+        in[i] = method.Type.In(i + 1)
+    }
+    out := make([]reflect.Type, method.Type.NumOut())
+    for i := range out {
+        out[i] = method.Type.Out(i)
+    }
+    return reflect.FuncOf(in, out, method.Type.IsVariadic())
+}
+
+// hasMethod reports whether typ has a method matching the interface method m.
+func hasMethod(typ reflect.Type, m reflect.Method) bool {
+    method, ok := typ.MethodByName(m.Name)
+    return ok && methodType(typ, method) == m.Type
+}
+
+func AssignableTo[A any](v any) (s string) {
+    target := reflect.TypeFor[A]()
+    typ := reflect.TypeOf(v)
+    if typ == nil || !typ.AssignableTo(target) {
+        s = "expected value to be assignable to type\n"
+        s += fmt.Sprintf("↪ type: %s\n", typeName(typ))
+        s += fmt.Sprintf("↪   to: %s\n", typeName(target))
+    }
+    return
+}
+
+func ConvertibleTo[A any](v any) (s string) {
+    target := reflect.TypeFor[A]()
+    typ := reflect.TypeOf(v)
+    if typ == nil || !typ.ConvertibleTo(target) {
+        s = "expected value to be convertible to type\n"
+        s += fmt.Sprintf("↪ type: %s\n", typeName(typ))
+        s += fmt.Sprintf("↪   to: %s\n", typeName(target))
+    }
+    return
+}
+
+func SameType(a, b any) (s string) {
+    if ta, tb := reflect.TypeOf(a), reflect.TypeOf(b); ta != tb {
+        s = "expected values of the same type\n"
+        s += fmt.Sprintf("↪ a: %s\n", typeName(ta))
+        s += fmt.Sprintf("↪ b: %s\n", typeName(tb))
+    }
+    return
+}
+
+func Kind(v any, kind reflect.Kind) (s string) {
+    if k := reflect.ValueOf(v).Kind(); k != kind {
+        s = "expected value of different kind\n"
+        s += fmt.Sprintf("↪ kind: %s, expected: %s\n", k, kind)
+        s += fmt.Sprintf("↪ type: %s\n", typeName(reflect.TypeOf(v)))
+    }
+    return
+}
@@ -0,0 +1,187 @@
+package exectest
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "os/exec"
+    "sync"
+    "time"
+)
+
+// envHelper names the environment variable selecting the helper to run in a
+// re-executed test binary.
+const envHelper = "GO_TEST_EXECTEST_HELPER"
+
+// waitDelay bounds how long to wait for output after the helper exits, in case
+// a process it started still holds the output pipes.
+const waitDelay = time.Second
+
+// ErrNoMain is returned when running a Cmd from a test binary whose TestMain
+// does not call Main.
+var ErrNoMain = errors.New("exectest: TestMain must call exectest.Main")
+
+var (
+    lock    sync.Mutex
+    helpers = make(map[string]func())
+    enabled bool
+)
+
+// Register makes fn available to run in a re-executed test binary under name.
+// It must be called before Main, typically from TestMain or an init function,
+// and panics if name is already registered.
+func Register(name string, fn func()) {
+    lock.Lock()
+    defer lock.Unlock()
+    if _, exists := helpers[name]; exists {
+        panic(fmt.Sprintf("exectest: helper %q already registered", name))
+    }
+    helpers[name] = fn
+}
+
+// Registered reports whether a helper is registered under name.
+func Registered(name string) bool {
+    lock.Lock()
+    defer lock.Unlock()
+    _, exists := helpers[name]
+    return exists
+}
+
+// Main runs the helper selected by a Cmd when the test binary was re-executed,
+// exiting with status 0 once the helper returns. Otherwise it runs the tests.
+// Call it from TestMain in place of os.Exit(m.Run()).
+func Main(m interface{ Run() int }) {
+    if name, ok := os.LookupEnv(envHelper); ok {
+        os.Exit(runHelper(name))
+    }
+    lock.Lock()
+    enabled = true
+    lock.Unlock()
+    os.Exit(m.Run())
+}
+
+func runHelper(name string) int {
+    lock.Lock()
+    fn, exists := helpers[name]
+    lock.Unlock()
+    if !exists {
+        fmt.Fprintf(os.Stderr, "exectest: no helper registered as %q\n", name)
+        return 2
+    }
+
+    // the helper sees only the arguments following "--"
+    args := []string{os.Args[0]}
+    for i, arg := range os.Args {
+        if arg == "--" {
+            args = append(args, os.Args[i+1:]...)
+            break
+        }
+    }
+    os.Args = args
+    fn()
+    return 0
+}
+
+// Cmd runs a registered helper in a re-executed test binary.
+type Cmd struct {
+    name    string
+    args    []string
+    env     []string
+    stdin   io.Reader
+    timeout time.Duration
+}
+
+// Command creates a Cmd running the helper registered as name, which sees args
+// as os.Args[1:].
+func Command(name string, args ...string) *Cmd {
+    return &Cmd{name: name, args: args}
+}
+
+// Stdin sets the standard input of the helper.
+func (c *Cmd) Stdin(r io.Reader) *Cmd {
+    c.stdin = r
+    return c
+}
+
+// Timeout sets how long the helper may run before it is killed.
+func (c *Cmd) Timeout(d time.Duration) *Cmd {
+    c.timeout = d
+    return c
+}
+
+// Env adds environment variables in the form "key=value" to the environment
+// of the helper, which otherwise inherits the environment of the test.
+func (c *Cmd) Env(env ...string) *Cmd {
+    c.env = append(c.env, env...)
+    return c
+}
+
+// Result is the outcome of running a Cmd.
+type Result struct {
+    // ExitCode is the exit code of the helper, or -1 if it was killed.
+    ExitCode int
+
+    // Stdout is the standard output of the helper.
+    Stdout string
+
+    // Stderr is the standard error of the helper.
+    Stderr string
+
+    // Killed reports whether the helper was terminated by a signal, such as
+    // when killed on timeout. A helper which exits by itself is not killed,
+    // even if it does so after the timeout.
+    Killed bool
+
+    // Err is set if the helper could not be run.
+    Err error
+}
+
+// Run runs the helper and waits for it to exit.
+func (c *Cmd) Run() *Result {
+    lock.Lock()
+    ready := enabled
+    _, exists := helpers[c.name]
+    lock.Unlock()
+    switch {
+    case !ready:
+        return &Result{ExitCode: -1, Err: ErrNoMain}
+    case !exists:
+        return &Result{ExitCode: -1, Err: fmt.Errorf("exectest: no helper registered as %q", c.name)}
+    }
+
+    exe, err := os.Executable()
+    if err != nil {
+        return &Result{ExitCode: -1, Err: err}
+    }
+
+    ctx := context.Background()
+    if c.timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, c.timeout)
+        defer cancel()
+    }
+
+    // -test.run=^$ keeps a binary which does not call Main from running tests
+    cmd := exec.CommandContext(ctx, exe, append([]string{"-test.run=^$", "--"}, c.args...)...)
+    cmd.Env = append(append(os.Environ(), envHelper+"="+c.name), c.env...)
+    cmd.Stdin = c.stdin
+    cmd.WaitDelay = waitDelay
+    var stdout, stderr bytes.Buffer
+    cmd.Stdout = &stdout
+    cmd.Stderr = &stderr
+
+    err = cmd.Run()
+    result := &Result{Stdout: stdout.String(), Stderr: stderr.String()}
+    if cmd.ProcessState == nil {
+        result.ExitCode = -1
+        result.Err = err
+        return result
+    }
+    result.ExitCode = cmd.ProcessState.ExitCode()
+    // ExitCode is -1 only if the process was terminated by a signal
+    result.Killed = result.ExitCode == -1
+    return result
+}
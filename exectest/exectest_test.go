@@ -0,0 +1,99 @@
+package exectest_test
+
+import (
+    "fmt"
+    "io"
+    "log"
+    "os"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/ninepeach/go-test/exectest"
+    "github.com/ninepeach/go-test/must"
+    "github.com/ninepeach/go-test/musttest"
+)
+
+func TestMain(m *testing.M) {
+    exectest.Register("echo", func() {
+        fmt.Println(strings.Join(os.Args[1:], " "))
+    })
+    exectest.Register("upper", func() {
+        b, _ := io.ReadAll(os.Stdin)
+        fmt.Print(strings.ToUpper(string(b)))
+    })
+    exectest.Register("exit", func() {
+        fmt.Fprintln(os.Stderr, "something went wrong")
+        os.Exit(3)
+    })
+    exectest.Register("fatal", func() {
+        log.Fatal("fatal error")
+    })
+    exectest.Register("env", func() {
+        fmt.Print(os.Getenv("GREETING"))
+    })
+    exectest.Register("hang", func() {
+        time.Sleep(time.Minute)
+    })
+    exectest.Main(m)
+}
+
+func TestCommand_args(t *testing.T) {
+    result := must.Command(t, "echo", "hello", "world").Run()
+    must.ExitCode(t, result, 0)
+    must.StdoutEq(t, result, "hello world\n")
+}
+
+func TestCommand_stdin(t *testing.T) {
+    result := must.Command(t, "upper").Stdin(strings.NewReader("shout")).Run()
+    must.StdoutEq(t, result, "SHOUT")
+}
+
+func TestCommand_exit(t *testing.T) {
+    result := must.Command(t, "exit").Timeout(time.Minute).Run()
+    must.ExitCode(t, result, 3)
+    must.False(t, result.Killed)
+    must.StderrContains(t, result, "something went wrong")
+}
+
+func TestCommand_fatal(t *testing.T) {
+    result := must.Command(t, "fatal").Run()
+    must.ExitCode(t, result, 1)
+    must.StderrContains(t, result, "fatal error")
+}
+
+func TestCommand_env(t *testing.T) {
+    result := must.Command(t, "env").Env("GREETING=hi").Run()
+    must.StdoutEq(t, result, "hi")
+}
+
+func TestCommand_timeout(t *testing.T) {
+    result := must.Command(t, "hang").Timeout(100 * time.Millisecond).Run()
+    must.Killed(t, result)
+}
+
+func TestCommand_failures(t *testing.T) {
+    t.Run("exit code", func(t *testing.T) {
+        rec := musttest.New(t)
+        must.ExitCode(rec, must.Command(t, "exit").Run(), 0)
+        rec.ExpectFailure(`stderr: "something went wrong"`)
+    })
+
+    t.Run("not killed", func(t *testing.T) {
+        rec := musttest.New(t)
+        must.Killed(rec, must.Command(t, "echo").Run())
+        rec.ExpectFailure("expected process to be killed")
+    })
+
+    t.Run("unregistered", func(t *testing.T) {
+        rec := musttest.New(t)
+        must.Command(rec, "missing")
+        rec.ExpectFailure(`no helper registered as "missing"`)
+    })
+
+    t.Run("run error", func(t *testing.T) {
+        rec := musttest.New(t)
+        must.StdoutEq(rec, exectest.Command("missing").Run(), "")
+        rec.ExpectFailure("failed to run command")
+    })
+}
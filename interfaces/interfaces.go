@@ -78,4 +78,10 @@ type EmptyFunc interface {
 // ContainsFunc satisfies types with Contains() method.
 type ContainsFunc[T any] interface {
     Contains(T) bool
-}
\ No newline at end of file
+}
+
+// Matcher represents a composable expectation over values of type A.
+type Matcher[A any] interface {
+    Match(A) bool
+    Describe() string
+}
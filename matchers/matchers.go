@@ -0,0 +1,164 @@
+package matchers
+
+import (
+    "fmt"
+    "reflect"
+    "regexp"
+    "strings"
+
+    "github.com/google/go-cmp/cmp"
+    "github.com/ninepeach/go-test/assertions"
+    "github.com/ninepeach/go-test/interfaces"
+)
+
+// matcher implements interfaces.Matcher from a match function and description.
+type matcher[A any] struct {
+    match       func(A) bool
+    description string
+}
+
+func (m *matcher[A]) Match(a A) bool {
+    return m.match(a)
+}
+
+func (m *matcher[A]) Describe() string {
+    return m.description
+}
+
+// Func creates a Matcher from the match function f, described by description.
+func Func[A any](description string, f func(A) bool) interfaces.Matcher[A] {
+    return &matcher[A]{match: f, description: description}
+}
+
+// passes adapts an assertion result into the boolean result of a match.
+func passes(result string) bool {
+    return result == ""
+}
+
+// EqualTo matches values equal to exp using cmp.Equal.
+func EqualTo[A any](exp A, opts ...cmp.Option) interfaces.Matcher[A] {
+    return Func("equal to "+assertions.Pretty(exp), func(val A) bool {
+        return passes(assertions.Eq(exp, val, opts...))
+    })
+}
+
+// HasLen matches slices, arrays, maps, strings and channels of length n, as
+// well as values implementing a Len() method returning n.
+func HasLen[A any](n int) interfaces.Matcher[A] {
+    return Func(fmt.Sprintf("has length %d", n), func(val A) bool {
+        if l, ok := any(val).(interfaces.LengthFunc); ok {
+            return passes(assertions.Length(n, l))
+        }
+        v := reflect.ValueOf(val)
+        switch v.Kind() {
+        case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+            return v.Len() == n
+        default:
+            return false
+        }
+    })
+}
+
+// ContainsElement matches slices containing element using cmp.Equal.
+func ContainsElement[S ~[]E, E any](element E, opts ...cmp.Option) interfaces.Matcher[S] {
+    return Func("contains element "+assertions.Pretty(element), func(val S) bool {
+        return passes(assertions.SliceContains(val, element, opts...))
+    })
+}
+
+// HasKey matches maps containing key.
+func HasKey[M ~map[K]V, K comparable, V any](key K) interfaces.Matcher[M] {
+    return Func("has key "+assertions.Pretty(key), func(val M) bool {
+        return passes(assertions.MapContainsKey(val, key))
+    })
+}
+
+// MatchesRegex matches strings matching the regular expression re.
+func MatchesRegex[S ~string](re *regexp.Regexp) interfaces.Matcher[S] {
+    return Func(fmt.Sprintf("matches regex %s", re), func(val S) bool {
+        return passes(assertions.RegexMatch(re, string(val)))
+    })
+}
+
+// AllOf matches values matched by each of matchers.
+func AllOf[A any](matchers ...interfaces.Matcher[A]) interfaces.Matcher[A] {
+    return Func(fmt.Sprintf("all of (%s)", describe(matchers)), func(val A) bool {
+        for _, m := range matchers {
+            if !m.Match(val) {
+                return false
+            }
+        }
+        return true
+    })
+}
+
+// AnyOf matches values matched by at least one of matchers.
+func AnyOf[A any](matchers ...interfaces.Matcher[A]) interfaces.Matcher[A] {
+    return Func(fmt.Sprintf("any of (%s)", describe(matchers)), func(val A) bool {
+        for _, m := range matchers {
+            if m.Match(val) {
+                return true
+            }
+        }
+        return false
+    })
+}
+
+// Not matches values not matched by m.
+func Not[A any](m interfaces.Matcher[A]) interfaces.Matcher[A] {
+    return Func(fmt.Sprintf("not (%s)", m.Describe()), func(val A) bool {
+        return !m.Match(val)
+    })
+}
+
+// Field matches structs, or pointers to structs, whose field name is of type F
+// and matched by m. If A is a struct type or a pointer to one, Field panics if
+// the field does not exist, is unexported, or does not hold an F. If A is an
+// interface type, values whose dynamic type lacks such a field do not match.
+func Field[A, F any](name string, m interfaces.Matcher[F]) interfaces.Matcher[A] {
+    typ := reflect.TypeFor[A]()
+    for typ.Kind() == reflect.Pointer {
+        typ = typ.Elem()
+    }
+    if typ.Kind() == reflect.Struct {
+        f, ok := typ.FieldByName(name)
+        target := reflect.TypeFor[F]()
+        switch {
+        case !ok:
+            panic(fmt.Sprintf("matchers: struct %s has no field %s", typ, name))
+        case !f.IsExported():
+            panic(fmt.Sprintf("matchers: field %s of struct %s is unexported", name, typ))
+        case f.Type != target && !(target.Kind() == reflect.Interface && f.Type.Implements(target)):
+            panic(fmt.Sprintf("matchers: field %s of struct %s is of type %s, not %s", name, typ, f.Type, target))
+        }
+    }
+    return Func(fmt.Sprintf("field %s %s", name, m.Describe()), func(val A) bool {
+        v := reflect.ValueOf(val)
+        for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+            if v.IsNil() {
+                return false
+            }
+            v = v.Elem()
+        }
+        if v.Kind() != reflect.Struct {
+            return false
+        }
+        f := v.FieldByName(name)
+        if !f.IsValid() || !f.CanInterface() {
+            return false
+        }
+        field, ok := f.Interface().(F)
+        if !ok {
+            return false
+        }
+        return m.Match(field)
+    })
+}
+
+func describe[A any](matchers []interfaces.Matcher[A]) string {
+    descriptions := make([]string, 0, len(matchers))
+    for _, m := range matchers {
+        descriptions = append(descriptions, m.Describe())
+    }
+    return strings.Join(descriptions, ", ")
+}
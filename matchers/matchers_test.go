@@ -0,0 +1,100 @@
+package matchers
+
+import (
+    "regexp"
+    "testing"
+
+    "github.com/ninepeach/go-test/interfaces"
+    "github.com/ninepeach/go-test/must"
+    "github.com/ninepeach/go-test/musttest"
+)
+
+type Person struct {
+    Name string
+    Age  int
+    tags []string
+}
+
+func TestMatchers(t *testing.T) {
+    cases := []struct {
+        name    string
+        matcher interfaces.Matcher[[]string]
+        val     []string
+        exp     bool
+    }{
+        {"equal", EqualTo([]string{"a", "b"}), []string{"a", "b"}, true},
+        {"not equal", EqualTo([]string{"a"}), []string{"a", "b"}, false},
+        {"len", HasLen[[]string](2), []string{"a", "b"}, true},
+        {"not len", HasLen[[]string](1), []string{"a", "b"}, false},
+        {"contains", ContainsElement[[]string]("b"), []string{"a", "b"}, true},
+        {"not contains", ContainsElement[[]string]("c"), []string{"a", "b"}, false},
+        {"all", AllOf(HasLen[[]string](2), ContainsElement[[]string]("a")), []string{"a", "b"}, true},
+        {"not all", AllOf(HasLen[[]string](2), ContainsElement[[]string]("c")), []string{"a", "b"}, false},
+        {"any", AnyOf(HasLen[[]string](5), ContainsElement[[]string]("a")), []string{"a", "b"}, true},
+        {"not any", AnyOf(HasLen[[]string](5), ContainsElement[[]string]("c")), []string{"a", "b"}, false},
+        {"not", Not(HasLen[[]string](5)), []string{"a", "b"}, true},
+    }
+
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            must.EqOp(t, tc.exp, tc.matcher.Match(tc.val))
+        })
+    }
+}
+
+func TestHasKey(t *testing.T) {
+    m := HasKey[map[string]int]("a")
+    must.True(t, m.Match(map[string]int{"a": 1}))
+    must.False(t, m.Match(map[string]int{"b": 1}))
+}
+
+func TestMatchesRegex(t *testing.T) {
+    m := MatchesRegex[string](regexp.MustCompile(`^h.*o$`))
+    must.True(t, m.Match("hello"))
+    must.False(t, m.Match("world"))
+    must.EqOp(t, "matches regex ^h.*o$", m.Describe())
+}
+
+func TestField(t *testing.T) {
+    name := Field[*Person]("Name", EqualTo("Alice"))
+    must.True(t, name.Match(&Person{Name: "Alice"}))
+    must.False(t, name.Match(&Person{Name: "Bob"}))
+    must.False(t, name.Match(nil))
+    must.EqOp(t, `field Name equal to "Alice"`, name.Describe())
+
+    age := Field[any]("Age", EqualTo(30))
+    must.True(t, age.Match(Person{Age: 30}))
+    must.False(t, age.Match(Person{Age: 31}))
+    must.False(t, age.Match("not a struct"))
+}
+
+func TestField_invalid(t *testing.T) {
+    cases := []struct {
+        name string
+        f    func()
+        exp  string
+    }{
+        {"type", func() { Field[Person]("Age", EqualTo("Alice")) }, "field Age of struct matchers.Person is of type int, not string"},
+        {"unexported", func() { Field[Person]("tags", HasLen[[]string](0)) }, "field tags of struct matchers.Person is unexported"},
+        {"missing", func() { Field[*Person]("Missing", EqualTo(1)) }, "struct matchers.Person has no field Missing"},
+    }
+    for _, tc := range cases {
+        t.Run(tc.name, func(t *testing.T) {
+            defer func() {
+                must.EqOp[any](t, "matchers: "+tc.exp, recover())
+            }()
+            tc.f()
+        })
+    }
+}
+
+func TestDescribe(t *testing.T) {
+    m := AllOf(Not(EqualTo(1)), AnyOf(EqualTo(2), EqualTo(3)))
+    must.EqOp(t, "all of (not (equal to 1), any of (equal to 2, equal to 3))", m.Describe())
+}
+
+func TestThat(t *testing.T) {
+    rec := musttest.New(t)
+    must.That(rec, "bob", MatchesRegex[string](regexp.MustCompile(`^a`)))
+    rec.ExpectFailure("↪ matcher: matches regex ^a")
+}
@@ -0,0 +1,93 @@
+package must
+
+import (
+    "fmt"
+    "runtime"
+    "strings"
+
+    "github.com/ninepeach/go-test/assertions"
+)
+
+// goroutineID returns the id of the calling goroutine, parsed from the
+// "goroutine N [status]:" header runtime.Stack writes. Go offers no supported
+// way to identify a goroutine, so this is a heuristic relying on the format of
+// that header, which has been stable since Go 1. It returns 0 if the header
+// cannot be parsed, which no goroutine has. It is only called when an
+// assertion fails, so its cost does not matter to passing tests.
+func goroutineID() uint64 {
+    buf := make([]byte, 64)
+    b := buf[:runtime.Stack(buf, false)]
+    const prefix = "goroutine "
+    if len(b) < len(prefix) || string(b[:len(prefix)]) != prefix {
+        return 0
+    }
+    var id uint64
+    for _, c := range b[len(prefix):] {
+        if c < '0' || c > '9' {
+            break
+        }
+        id = id*10 + uint64(c-'0')
+    }
+    return id
+}
+
+// async is the T returned by Async.
+type async struct {
+    collector
+    t       T
+    id      uint64
+    helpers assertions.Helpers
+}
+
+func (a *async) Helper() {}
+
+func (a *async) marks() *assertions.Helpers {
+    return &a.helpers
+}
+
+func (a *async) Fatalf(msg string, args ...any) {
+    if a.id != 0 && goroutineID() == a.id {
+        a.t.Helper()
+        a.t.Fatalf(msg, args...)
+        return
+    }
+    a.collector.Fatalf(msg, args...)
+}
+
+// report fails the test with the failures recorded off the test goroutine.
+func (a *async) report() {
+    failures := a.Failures()
+    if len(failures) == 0 {
+        return
+    }
+    s := fmt.Sprintf("%d failure(s) on other goroutine(s)\n", len(failures))
+    for _, failure := range failures {
+        s += "\n" + failure + "\n"
+    }
+    errorf(a.t, "%s", "\n"+strings.TrimSpace(s)+"\n")
+}
+
+// Async returns a T for making assertions from goroutines other than the test
+// goroutine, where calling t.Fatalf is invalid. A failed assertion made on
+// another goroutine is recorded and stops only that goroutine; the recorded
+// failures are reported on the test goroutine when the test completes.
+// Assertions made on the test goroutine fail the test immediately as usual.
+//
+// The test goroutine is told apart by goroutineID, since a failure there must
+// go to t.Fatalf: stopping the test goroutine by other means crashes the test
+// binary. If the id cannot be determined, every failure is recorded instead.
+//
+// Async must be called from the test goroutine with a t supporting Cleanup.
+// Goroutines should be done before the test completes, or their failures are
+// lost.
+func Async(t T) T {
+    t.Helper()
+    a := &async{t: t, id: goroutineID()}
+    c, ok := t.(interface{ Cleanup(func()) })
+    if !ok {
+        errorf(t, "%s", "\nAsync requires a T supporting Cleanup\n")
+        return a
+    }
+    c.Cleanup(a.report)
+    return a
+}
@@ -0,0 +1,76 @@
+package must
+
+import (
+    "strings"
+    "sync"
+    "testing"
+
+    "github.com/ninepeach/go-test/musttest"
+)
+
+func TestAsync(t *testing.T) {
+    t.Run("pass", func(t *testing.T) {
+        rec := musttest.New(t)
+        at := Async(rec)
+        var wg sync.WaitGroup
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            True(at, true)
+        }()
+        wg.Wait()
+        rec.RunCleanups()
+        SliceEmpty(t, rec.Failures())
+    })
+
+    t.Run("goroutine", func(t *testing.T) {
+        rec := musttest.New(t)
+        at := Async(rec)
+        reached := false
+        var wg sync.WaitGroup
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            EqOp(at, 1, 2)
+            reached = true
+        }()
+        wg.Wait()
+
+        False(t, reached)
+        SliceEmpty(t, rec.Failures())
+        rec.RunCleanups()
+        failures := rec.Failures()
+        SliceLen(t, 1, failures)
+        for _, sub := range []string{"1 failure(s) on other goroutine(s)", "async_test.go:", "expected equality via =="} {
+            if !strings.Contains(failures[0], sub) {
+                t.Fatalf("expected %q in output, got:\n%s", sub, failures[0])
+            }
+        }
+    })
+
+    t.Run("test goroutine", func(t *testing.T) {
+        rec := musttest.New(t)
+        at := Async(rec)
+        EqOp(at, 1, 2)
+        SliceLen(t, 1, rec.Failures())
+        rec.RunCleanups()
+        SliceLen(t, 1, rec.Failures())
+    })
+
+    t.Run("goroutine id", func(t *testing.T) {
+        id := goroutineID()
+        True(t, id != 0)
+        other := make(chan uint64)
+        go func() {
+            other <- goroutineID()
+        }()
+        True(t, id != <-other)
+    })
+
+    t.Run("no cleanup", func(t *testing.T) {
+        tc := newCase(t, "Async requires a T supporting Cleanup")
+        t.Cleanup(tc.assert)
+
+        Async(tc)
+    })
+}
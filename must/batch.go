@@ -0,0 +1,54 @@
+package must
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/ninepeach/go-test/assertions"
+)
+
+// B is the T given to the function passed to Batch. Assertions made against a
+// B record their failure and return, so the remaining assertions still run.
+type B interface {
+    T
+
+    // Failed reports whether any assertion in the batch has failed so far.
+    Failed() bool
+}
+
+// batch implements B by collecting failure messages.
+type batch struct {
+    failures []string
+    helpers  assertions.Helpers
+}
+
+func (b *batch) Helper() {}
+
+func (b *batch) marks() *assertions.Helpers {
+    return &b.helpers
+}
+
+func (b *batch) Fatalf(msg string, args ...any) {
+    b.failures = append(b.failures, strings.TrimSpace(fmt.Sprintf(msg, args...)))
+}
+
+func (b *batch) Failed() bool {
+    return len(b.failures) > 0
+}
+
+// Batch runs fn, collecting the failure of every assertion made against b
+// instead of stopping at the first one. Once fn returns, any failures are
+// reported together, each with its own caller line, and the test is stopped.
+func Batch(t T, fn func(b B)) {
+    t.Helper()
+    b := new(batch)
+    fn(b)
+    if !b.Failed() {
+        return
+    }
+    s := fmt.Sprintf("%d batched assertion(s) failed\n", len(b.failures))
+    for _, failure := range b.failures {
+        s += "\n" + failure + "\n"
+    }
+    errorf(t, "%s", "\n"+strings.TrimSpace(s)+"\n")
+}
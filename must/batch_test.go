@@ -0,0 +1,44 @@
+package must
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestBatch(t *testing.T) {
+    t.Run("failures", func(t *testing.T) {
+        tc := newCase(t, `2 batched assertion(s) failed`)
+        t.Cleanup(tc.assert)
+
+        reached := false
+        Batch(tc, func(b B) {
+            Eq(b, 1, 2)
+            True(b, true)
+            EqOp(b, "foo", "bar")
+            reached = b.Failed()
+        })
+
+        if !reached {
+            t.Fatal("expected batch to continue after failure")
+        }
+        for _, exp := range []string{
+            "expected equality via cmp.Equal function",
+            "expected equality via ==",
+            "batch_test.go:",
+        } {
+            if !strings.Contains(tc.capture, exp) {
+                t.Fatalf("expected %q in output, got %q", exp, tc.capture)
+            }
+        }
+    })
+
+    t.Run("passing", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        Batch(tc, func(b B) {
+            Eq(b, 1, 1)
+            True(b, true)
+        })
+    })
+}
@@ -0,0 +1,31 @@
+package must
+
+import (
+    "github.com/ninepeach/go-test/assertions"
+)
+
+// BytesEq asserts exp and val contain the same bytes. Failures show a side by
+// side hex dump of both, marking the bytes which differ.
+func BytesEq(t T, exp, val []byte, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.BytesEq(exp, val), settings...)
+}
+
+// BytesEqHex asserts val contains the bytes encoded by the hex string exp.
+// Whitespace in exp is ignored, so it may be grouped for readability.
+func BytesEqHex(t T, exp string, val []byte, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.BytesEqHex(exp, val), settings...)
+}
+
+// BytesHasPrefix asserts val begins with prefix.
+func BytesHasPrefix(t T, val, prefix []byte, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.BytesHasPrefix(val, prefix), settings...)
+}
+
+// BytesContains asserts val contains sub.
+func BytesContains(t T, val, sub []byte, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.BytesContains(val, sub), settings...)
+}
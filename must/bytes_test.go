@@ -0,0 +1,80 @@
+package must
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+)
+
+func TestBytesEq(t *testing.T) {
+    t.Run("equal", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        BytesEq(tc, []byte("abc"), []byte("abc"))
+    })
+
+    t.Run("difference", func(t *testing.T) {
+        tc := newCase(t, `expected equality of bytes`)
+        t.Cleanup(tc.assert)
+
+        exp := bytes.Repeat([]byte("0123456789abcdef"), 64)
+        val := bytes.Clone(exp)
+        val[500] = 'X'
+        BytesEq(tc, exp, val)
+
+        for _, sub := range []string{
+            "⋮ 29 equal row(s) elided",
+            "000001f0  30 31 32 33 34 35 36 37  38 39 61 62 63 64 65 66  |0123456789abcdef|  30 31 32 33 58 35 36 37  38 39 61 62 63 64 65 66  |0123X56789abcdef|",
+            "⋮ 30 equal row(s) elided",
+        } {
+            if !strings.Contains(tc.capture, sub) {
+                t.Fatalf("expected %q in output, got:\n%s", sub, tc.capture)
+            }
+        }
+    })
+
+    t.Run("length", func(t *testing.T) {
+        tc := newCase(t, `len(exp): 3, len(val): 4`)
+        t.Cleanup(tc.assert)
+
+        BytesEq(tc, []byte("abc"), []byte("abcd"))
+    })
+}
+
+func TestBytesEqHex(t *testing.T) {
+    t.Run("equal", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        BytesEqHex(tc, "de ad\nbe ef", []byte{0xde, 0xad, 0xbe, 0xef})
+    })
+
+    t.Run("difference", func(t *testing.T) {
+        tc := newCase(t, `expected equality of bytes via hex`)
+        t.Cleanup(tc.assert)
+
+        BytesEqHex(tc, "deadbeef", []byte{0xde, 0xad, 0xbe, 0xee})
+    })
+
+    t.Run("invalid", func(t *testing.T) {
+        tc := newCase(t, `failed to decode expected hex`)
+        t.Cleanup(tc.assert)
+
+        BytesEqHex(tc, "xyz", nil)
+    })
+}
+
+func TestBytesHasPrefix(t *testing.T) {
+    tc := newCase(t, `expected bytes to have prefix`)
+    t.Cleanup(tc.assert)
+
+    BytesHasPrefix(tc, []byte("hello"), []byte("help"))
+}
+
+func TestBytesContains(t *testing.T) {
+    tc := newCase(t, `expected bytes to contain subslice`)
+    t.Cleanup(tc.assert)
+
+    BytesContains(tc, []byte("hello"), []byte("world"))
+}
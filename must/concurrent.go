@@ -0,0 +1,147 @@
+package must
+
+import (
+    "fmt"
+    "runtime"
+    "strings"
+    "sync"
+    "sync/atomic"
+
+    "github.com/ninepeach/go-test/assertions"
+)
+
+// collector is a T which may be shared by goroutines other than the test
+// goroutine. Failures are recorded, and Fatalf stops the calling goroutine
+// like testing.T.Fatalf would, so they can be reported on the test goroutine
+// afterwards.
+type collector struct {
+    lock     sync.Mutex
+    failures []string
+}
+
+func (c *collector) Helper() {}
+
+func (c *collector) Fatalf(msg string, args ...any) {
+    c.record(fmt.Sprintf(msg, args...))
+    runtime.Goexit()
+}
+
+func (c *collector) record(failure string) {
+    c.lock.Lock()
+    defer c.lock.Unlock()
+    c.failures = append(c.failures, strings.TrimSpace(failure))
+}
+
+func (c *collector) Failures() []string {
+    c.lock.Lock()
+    defer c.lock.Unlock()
+    return append([]string(nil), c.failures...)
+}
+
+// goroutine is the T given to each goroutine started by Concurrently, which
+// labels its failures with the index of the goroutine.
+type goroutine struct {
+    c       *collector
+    i       int
+    helpers assertions.Helpers
+}
+
+func (g *goroutine) Helper() {}
+
+func (g *goroutine) marks() *assertions.Helpers {
+    return &g.helpers
+}
+
+func (g *goroutine) Fatalf(msg string, args ...any) {
+    g.c.Fatalf("goroutine %d: %s", g.i, strings.TrimSpace(fmt.Sprintf(msg, args...)))
+}
+
+// Concurrently runs fn in n goroutines, each given its own index i. The
+// goroutines wait at a barrier until all have started, to maximize contention.
+// Assertions made against the T given to fn stop only that goroutine. Once all
+// goroutines are done, their failures and panics are reported together on the
+// test goroutine, and the test is stopped. A negative n fails the test.
+func Concurrently(t T, n int, fn func(t T, i int)) {
+    t.Helper()
+    if n < 0 {
+        invoke(t, fmt.Sprintf("expected non-negative number of goroutines\n↪ n: %d\n", n))
+        return
+    }
+    c := new(collector)
+    var ready, done sync.WaitGroup
+    start := make(chan struct{})
+    ready.Add(n)
+    done.Add(n)
+    for i := 0; i < n; i++ {
+        go func() {
+            defer done.Done()
+            defer func() {
+                if p := recover(); p != nil {
+                    c.record(fmt.Sprintf("goroutine %d: panic: %v", i, p))
+                }
+            }()
+            ready.Done()
+            <-start
+            fn(&goroutine{c: c, i: i}, i)
+        }()
+    }
+    ready.Wait()
+    close(start)
+    done.Wait()
+
+    failures := c.Failures()
+    if len(failures) == 0 {
+        return
+    }
+    s := fmt.Sprintf("%d failure(s) across %d goroutine(s)\n", len(failures), n)
+    for _, failure := range failures {
+        s += "\n" + failure + "\n"
+    }
+    errorf(t, "%s", "\n"+strings.TrimSpace(s)+"\n")
+}
+
+// History records the operations performed concurrently against the system
+// under test, for checking with Linearizable. It is safe for concurrent use.
+type History struct {
+    clock atomic.Int64
+
+    lock sync.Mutex
+    ops  []assertions.Op
+}
+
+// Record runs the operation fn on behalf of client, recording input and the
+// result of fn along with when the operation was called and returned.
+func (h *History) Record(client int, input any, fn func() any) any {
+    call := h.clock.Add(1)
+    output := fn()
+    ret := h.clock.Add(1)
+
+    h.lock.Lock()
+    defer h.lock.Unlock()
+    h.ops = append(h.ops, assertions.Op{
+        Client: client,
+        Input:  input,
+        Output: output,
+        Call:   call,
+        Return: ret,
+    })
+    return output
+}
+
+// Ops returns the recorded operations.
+func (h *History) Ops() []assertions.Op {
+    h.lock.Lock()
+    defer h.lock.Unlock()
+    return append([]assertions.Op(nil), h.ops...)
+}
+
+// Linearizable asserts the operations recorded by h could have taken effect
+// atomically in some order consistent with when they were called and returned.
+// The sequential model is given by step, which applies an operation with input
+// to state and reports whether output is what the model produces, along with
+// the next state. Starting from init, the search backtracks over possible
+// orders, so keep histories to a few dozen operations.
+func Linearizable[S any](t T, h *History, init S, step func(state S, input, output any) (S, bool), settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.Linearizable(init, step, h.Ops()), settings...)
+}
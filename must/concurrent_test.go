@@ -0,0 +1,115 @@
+package must
+
+import (
+    "strings"
+    "sync"
+    "sync/atomic"
+    "testing"
+)
+
+func TestConcurrently(t *testing.T) {
+    t.Run("pass", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        var count atomic.Int64
+        Concurrently(tc, 8, func(t T, i int) {
+            count.Add(1)
+        })
+        Eq(t, 8, count.Load())
+    })
+
+    t.Run("negative", func(t *testing.T) {
+        tc := newCase(t, "expected non-negative number of goroutines\n↪ n: -1")
+        t.Cleanup(tc.assert)
+
+        Concurrently(tc, -1, func(t T, i int) {})
+    })
+
+    t.Run("failures", func(t *testing.T) {
+        tc := newCapture(t)
+
+        var after atomic.Int64
+        Concurrently(tc, 4, func(t T, i int) {
+            switch i {
+            case 1:
+                Eq(t, 0, i)
+                after.Add(1)
+            case 3:
+                panic("boom")
+            }
+        })
+
+        Eq(t, 0, after.Load())
+        for _, sub := range []string{
+            "2 failure(s) across 4 goroutine(s)",
+            "goroutine 1: concurrent_test.go:",
+            "goroutine 3: panic: boom",
+        } {
+            if !strings.Contains(tc.capture, sub) {
+                t.Fatalf("expected %q in output, got:\n%s", sub, tc.capture)
+            }
+        }
+    })
+}
+
+// register is a sequential model of a single value register, where an input of
+// nil reads the value and any other input writes it.
+func register(state, input, output any) (any, bool) {
+    if input == nil {
+        return state, output == state
+    }
+    return input, true
+}
+
+func TestLinearizable(t *testing.T) {
+    t.Run("mutex", func(t *testing.T) {
+        var (
+            mu    sync.Mutex
+            value any = 0
+            h     History
+        )
+        Concurrently(t, 4, func(t T, i int) {
+            for j := 0; j < 3; j++ {
+                var input any
+                if j%2 == 0 {
+                    input = i*10 + j
+                }
+                h.Record(i, input, func() any {
+                    mu.Lock()
+                    defer mu.Unlock()
+                    if input == nil {
+                        return value
+                    }
+                    value = input
+                    return nil
+                })
+            }
+        })
+        SliceLen(t, 12, h.Ops())
+        Linearizable(t, &h, any(0), register)
+    })
+
+    t.Run("stale read", func(t *testing.T) {
+        tc := newCase(t, `expected history to be linearizable`)
+        t.Cleanup(tc.assert)
+
+        var h History
+        h.Record(0, 1, func() any { return nil })
+        h.Record(1, nil, func() any { return 0 })
+        Linearizable(tc, &h, any(0), register)
+    })
+
+    t.Run("overlapping", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        // a read overlapping a write may observe either value
+        var h History
+        h.Record(0, 1, func() any {
+            h.Record(1, nil, func() any { return 1 })
+            return nil
+        })
+        Linearizable(tc, &h, any(0), register)
+    })
+}
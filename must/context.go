@@ -0,0 +1,83 @@
+package must
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    "github.com/ninepeach/go-test/assertions"
+)
+
+// maxContextMargin caps the margin left between the deadline of a context
+// created by Context and the deadline of the test.
+const maxContextMargin = 5 * time.Second
+
+// errContextMargin is the cause of a context created by Context expiring ahead
+// of the test deadline.
+var errContextMargin = errors.New("test deadline margin reached")
+
+// Context returns a context which is canceled when the test completes, if t
+// supports cleanup. If t has a deadline, as set by go test -timeout, the
+// context expires a safety margin before it, so that a hung test fails with a
+// useful message rather than the whole test binary panicking on timeout. The
+// cause of such an expiry, as reported by context.Cause, says so.
+func Context(t T) context.Context {
+    t.Helper()
+    ctx := context.Background()
+    if c, ok := t.(interface{ Context() context.Context }); ok {
+        ctx = c.Context()
+    }
+
+    var cancels []context.CancelFunc
+    if d, ok := t.(interface{ Deadline() (time.Time, bool) }); ok {
+        if deadline, ok := d.Deadline(); ok {
+            margin := max(0, min(time.Until(deadline)/10, maxContextMargin))
+            var cancel context.CancelFunc
+            ctx, cancel = context.WithDeadlineCause(ctx, deadline.Add(-margin), errContextMargin)
+            cancels = append(cancels, cancel)
+        }
+    }
+    ctx, cancel := context.WithCancel(ctx)
+    cancels = append(cancels, cancel)
+    if c, ok := t.(interface{ Cleanup(func()) }); ok {
+        c.Cleanup(func() {
+            for _, cancel := range cancels {
+                cancel()
+            }
+        })
+    }
+    return ctx
+}
+
+// ContextCanceled asserts ctx has been canceled.
+func ContextCanceled(t T, ctx context.Context, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.ContextCanceled(ctx), settings...)
+}
+
+// ContextDeadlineExceeded asserts the deadline of ctx has been exceeded.
+func ContextDeadlineExceeded(t T, ctx context.Context, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.ContextDeadlineExceeded(ctx), settings...)
+}
+
+// ContextNotDone asserts ctx has neither been canceled nor exceeded its
+// deadline.
+func ContextNotDone(t T, ctx context.Context, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.ContextNotDone(ctx), settings...)
+}
+
+// ContextHasValue asserts ctx carries a value of type A for key, equal to exp
+// using cmp.Equal.
+func ContextHasValue[A any](t T, ctx context.Context, key any, exp A, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.ContextHasValue(ctx, key, exp, options(settings...)...), settings...)
+}
+
+// ContextDeadlineWithin asserts ctx has a deadline at most d from now.
+func ContextDeadlineWithin(t T, ctx context.Context, d time.Duration, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.ContextDeadlineWithin(ctx, d), settings...)
+}
+
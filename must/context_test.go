@@ -0,0 +1,168 @@
+package must
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/ninepeach/go-test/musttest"
+)
+
+type ctxKey string
+
+// deadlineT is a test with a fixed deadline.
+type deadlineT struct {
+    *musttest.T
+    deadline time.Time
+}
+
+func (t deadlineT) Deadline() (time.Time, bool) {
+    return t.deadline, true
+}
+
+func TestContext(t *testing.T) {
+    t.Run("canceled at cleanup", func(t *testing.T) {
+        rec := musttest.New(t)
+        ctx := Context(rec)
+        ContextNotDone(t, ctx)
+        rec.RunCleanups()
+        ContextCanceled(t, ctx)
+    })
+
+    t.Run("deadline", func(t *testing.T) {
+        deadline, ok := t.Deadline()
+        if !ok {
+            t.Skip("test has no deadline")
+        }
+        ctx := Context(t)
+        got, ok := ctx.Deadline()
+        True(t, ok)
+        True(t, got.Before(deadline))
+    })
+
+    t.Run("deadline passed", func(t *testing.T) {
+        rec := musttest.New(t)
+        deadline := time.Now().Add(-time.Minute)
+        ctx := Context(deadlineT{T: rec, deadline: deadline})
+        t.Cleanup(rec.RunCleanups)
+
+        got, ok := ctx.Deadline()
+        True(t, ok)
+        Eq(t, deadline, got)
+    })
+
+    t.Run("margin cause", func(t *testing.T) {
+        rec := musttest.New(t)
+        ctx := Context(deadlineT{T: rec, deadline: time.Now().Add(10 * time.Millisecond)})
+        t.Cleanup(rec.RunCleanups)
+
+        <-ctx.Done()
+        True(t, errors.Is(context.Cause(ctx), errContextMargin))
+        True(t, errors.Is(ctx.Err(), context.DeadlineExceeded))
+    })
+}
+
+func TestContextCanceled(t *testing.T) {
+    t.Run("not canceled", func(t *testing.T) {
+        tc := newCase(t, `expected context to be canceled`)
+        t.Cleanup(tc.assert)
+
+        ContextCanceled(tc, context.Background())
+    })
+
+    t.Run("canceled", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        ctx, cancel := context.WithCancelCause(context.Background())
+        cancel(errors.New("shutting down"))
+        ContextCanceled(tc, ctx)
+    })
+
+    t.Run("cause", func(t *testing.T) {
+        tc := newCase(t, `cause: shutting down`)
+        t.Cleanup(tc.assert)
+
+        ctx, cancel := context.WithCancelCause(context.Background())
+        cancel(errors.New("shutting down"))
+        ContextNotDone(tc, ctx)
+    })
+}
+
+func TestContextDeadlineExceeded(t *testing.T) {
+    t.Run("exceeded", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+        defer cancel()
+        <-ctx.Done()
+        ContextDeadlineExceeded(tc, ctx)
+    })
+
+    t.Run("not done", func(t *testing.T) {
+        tc := newCase(t, `↪ context: not done`)
+        t.Cleanup(tc.assert)
+
+        ContextDeadlineExceeded(tc, context.Background())
+    })
+}
+
+func TestContextHasValue(t *testing.T) {
+    ctx := context.WithValue(context.Background(), ctxKey("user"), "alice")
+
+    t.Run("equal", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        ContextHasValue(tc, ctx, ctxKey("user"), "alice")
+    })
+
+    t.Run("not equal", func(t *testing.T) {
+        tc := newCase(t, `expected equality of context value`)
+        t.Cleanup(tc.assert)
+
+        ContextHasValue(tc, ctx, ctxKey("user"), "bob")
+    })
+
+    t.Run("wrong type", func(t *testing.T) {
+        tc := newCase(t, `type: string, expected: int`)
+        t.Cleanup(tc.assert)
+
+        ContextHasValue(tc, ctx, ctxKey("user"), 1)
+    })
+
+    t.Run("missing", func(t *testing.T) {
+        tc := newCase(t, `key: "role"`)
+        t.Cleanup(tc.assert)
+
+        ContextHasValue(tc, ctx, ctxKey("role"), "admin")
+    })
+}
+
+func TestContextDeadlineWithin(t *testing.T) {
+    ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+    t.Cleanup(cancel)
+
+    t.Run("within", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        ContextDeadlineWithin(tc, ctx, 2*time.Minute)
+    })
+
+    t.Run("too late", func(t *testing.T) {
+        tc := newCase(t, `expected context deadline within duration`)
+        t.Cleanup(tc.assert)
+
+        ContextDeadlineWithin(tc, ctx, time.Second)
+    })
+
+    t.Run("no deadline", func(t *testing.T) {
+        tc := newCase(t, `expected context to have a deadline`)
+        t.Cleanup(tc.assert)
+
+        ContextDeadlineWithin(tc, context.Background(), time.Second)
+    })
+}
@@ -0,0 +1,62 @@
+package must
+
+import (
+    "fmt"
+
+    "github.com/ninepeach/go-test/assertions"
+    "github.com/ninepeach/go-test/exectest"
+)
+
+// Command creates a Cmd running the helper registered with exectest.Register as
+// name in a re-executed test binary. The test binary must call exectest.Main
+// from TestMain.
+func Command(t T, name string, args ...string) *exectest.Cmd {
+    t.Helper()
+    if !exectest.Registered(name) {
+        invoke(t, fmt.Sprintf("no helper registered as %q via exectest.Register", name))
+    }
+    return exectest.Command(name, args...)
+}
+
+// ran checks result represents a helper which actually ran.
+func ran(result *exectest.Result) (s string) {
+    if result.Err != nil {
+        s = "failed to run command\n"
+        s += fmt.Sprintf("↪ error: %v\n", result.Err)
+    }
+    return
+}
+
+// first returns the first failure of results.
+func first(results ...string) string {
+    for _, result := range results {
+        if result != "" {
+            return result
+        }
+    }
+    return ""
+}
+
+// ExitCode asserts the helper exited with code exp.
+func ExitCode(t T, result *exectest.Result, exp int, settings ...Setting) {
+    t.Helper()
+    invoke(t, first(ran(result), assertions.ExitCode(exp, result.ExitCode, result.Stderr)), settings...)
+}
+
+// StdoutEq asserts the standard output of the helper is exactly exp.
+func StdoutEq(t T, result *exectest.Result, exp string, settings ...Setting) {
+    t.Helper()
+    invoke(t, first(ran(result), assertions.StdoutEq(exp, result.Stdout)), settings...)
+}
+
+// StderrContains asserts the standard error of the helper contains sub.
+func StderrContains(t T, result *exectest.Result, sub string, settings ...Setting) {
+    t.Helper()
+    invoke(t, first(ran(result), assertions.StderrContains(result.Stderr, sub)), settings...)
+}
+
+// Killed asserts the helper was killed, by timeout or signal.
+func Killed(t T, result *exectest.Result, settings ...Setting) {
+    t.Helper()
+    invoke(t, first(ran(result), assertions.Killed(result.Killed, result.ExitCode)), settings...)
+}
@@ -0,0 +1,120 @@
+package must
+
+import (
+    "io/fs"
+
+    "github.com/ninepeach/go-test/assertions"
+)
+
+// FileExists asserts file exists on the OS filesystem and is not a directory.
+// Failures list the entries of the directory file was expected in.
+func FileExists(t T, file string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileExists(file), settings...)
+}
+
+// FileExistsFS asserts file exists in system and is not a directory.
+func FileExistsFS(t T, system fs.FS, file string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileExistsFS(system, file), settings...)
+}
+
+// DirExists asserts directory dir exists on the OS filesystem.
+func DirExists(t T, dir string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.DirExists(dir), settings...)
+}
+
+// DirExistsFS asserts directory dir exists in system.
+func DirExistsFS(t T, system fs.FS, dir string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.DirExistsFS(system, dir), settings...)
+}
+
+// FileNotExists asserts file does not exist on the OS filesystem.
+func FileNotExists(t T, file string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileNotExists(file), settings...)
+}
+
+// FileNotExistsFS asserts file does not exist in system.
+func FileNotExistsFS(t T, system fs.FS, file string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileNotExistsFS(system, file), settings...)
+}
+
+// FileMode asserts file on the OS filesystem has exactly mode, including the
+// type bits. Symbolic links are not followed.
+func FileMode(t T, file string, mode fs.FileMode, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileMode(file, mode), settings...)
+}
+
+// FileModeFS asserts file in system has exactly mode, including the type bits.
+func FileModeFS(t T, system fs.FS, file string, mode fs.FileMode, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileModeFS(system, file, mode), settings...)
+}
+
+// FileSize asserts file on the OS filesystem is size bytes long.
+func FileSize(t T, file string, size int64, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileSize(file, size), settings...)
+}
+
+// FileSizeFS asserts file in system is size bytes long.
+func FileSizeFS(t T, system fs.FS, file string, size int64, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileSizeFS(system, file, size), settings...)
+}
+
+// FileContains asserts file on the OS filesystem contains content.
+func FileContains(t T, file, content string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileContains(file, content), settings...)
+}
+
+// FileContainsFS asserts file in system contains content.
+func FileContainsFS(t T, system fs.FS, file, content string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileContainsFS(system, file, content), settings...)
+}
+
+// FileEq asserts the content of file on the OS filesystem is exactly content.
+func FileEq(t T, file, content string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileEq(file, content), settings...)
+}
+
+// FileEqFS asserts the content of file in system is exactly content.
+func FileEqFS(t T, system fs.FS, file, content string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileEqFS(system, file, content), settings...)
+}
+
+// DirContainsFiles asserts directory dir on the OS filesystem contains each of
+// files, given relative to dir.
+func DirContainsFiles(t T, dir string, files []string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.DirContainsFiles(dir, files), settings...)
+}
+
+// DirContainsFilesFS asserts directory dir in system contains each of files,
+// given relative to dir.
+func DirContainsFilesFS(t T, system fs.FS, dir string, files []string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.DirContainsFilesFS(system, dir, files), settings...)
+}
+
+// FileSymlinkTo asserts link on the OS filesystem is a symbolic link to target.
+func FileSymlinkTo(t T, link, target string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileSymlinkTo(link, target), settings...)
+}
+
+// FileSymlinkToFS asserts link in system is a symbolic link to target. system
+// must implement ReadLink and Lstat, like os.DirFS in newer versions of Go.
+func FileSymlinkToFS(t T, system fs.FS, link, target string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.FileSymlinkToFS(system, link, target), settings...)
+}
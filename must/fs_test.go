@@ -0,0 +1,196 @@
+package must
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+    "testing/fstest"
+)
+
+var testFS = fstest.MapFS{
+    "config/app.yaml":  {Data: []byte("name: app\nport: 8080\n"), Mode: 0o644},
+    "config/db.yaml":   {Data: []byte("host: localhost\n"), Mode: 0o600},
+    "config/extra/a":   {Data: []byte("a")},
+    "bin/run.sh":       {Data: []byte("#!/bin/sh\n"), Mode: 0o755},
+    "empty/.gitignore": {},
+}
+
+func TestFileExistsFS(t *testing.T) {
+    t.Run("exists", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        FileExistsFS(tc, testFS, "config/app.yaml")
+    })
+
+    t.Run("typo", func(t *testing.T) {
+        tc := newCase(t, `entries of "config": app.yaml, db.yaml, extra/`)
+        t.Cleanup(tc.assert)
+
+        FileExistsFS(tc, testFS, "config/app.yml")
+    })
+
+    t.Run("directory", func(t *testing.T) {
+        tc := newCase(t, `expected file but found a directory`)
+        t.Cleanup(tc.assert)
+
+        FileExistsFS(tc, testFS, "config")
+    })
+}
+
+func TestDirExistsFS(t *testing.T) {
+    t.Run("exists", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        DirExistsFS(tc, testFS, "config/extra")
+    })
+
+    t.Run("missing", func(t *testing.T) {
+        tc := newCase(t, `expected directory to exist`)
+        t.Cleanup(tc.assert)
+
+        DirExistsFS(tc, testFS, "configs")
+    })
+
+    t.Run("file", func(t *testing.T) {
+        tc := newCase(t, `expected directory but found a file`)
+        t.Cleanup(tc.assert)
+
+        DirExistsFS(tc, testFS, "bin/run.sh")
+    })
+}
+
+func TestFileNotExistsFS(t *testing.T) {
+    t.Run("missing", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        FileNotExistsFS(tc, testFS, "config/missing")
+    })
+
+    t.Run("exists", func(t *testing.T) {
+        tc := newCase(t, `expected file to not exist`)
+        t.Cleanup(tc.assert)
+
+        FileNotExistsFS(tc, testFS, "config/db.yaml")
+    })
+}
+
+func TestFileModeFS(t *testing.T) {
+    t.Run("equal", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        FileModeFS(tc, testFS, "bin/run.sh", 0o755)
+    })
+
+    t.Run("different", func(t *testing.T) {
+        tc := newCase(t, `mode: -rw-------, expected: -rw-r--r--`)
+        t.Cleanup(tc.assert)
+
+        FileModeFS(tc, testFS, "config/db.yaml", 0o644)
+    })
+}
+
+func TestFileSizeFS(t *testing.T) {
+    t.Run("equal", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        FileSizeFS(tc, testFS, "config/extra/a", 1)
+    })
+
+    t.Run("different", func(t *testing.T) {
+        tc := newCase(t, `size: 1, expected: 2`)
+        t.Cleanup(tc.assert)
+
+        FileSizeFS(tc, testFS, "config/extra/a", 2)
+    })
+}
+
+func TestFileContainsFS(t *testing.T) {
+    t.Run("contains", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        FileContainsFS(tc, testFS, "config/app.yaml", "port: 8080")
+    })
+
+    t.Run("missing", func(t *testing.T) {
+        tc := newCase(t, `content: "port: 9090"`)
+        t.Cleanup(tc.assert)
+
+        FileContainsFS(tc, testFS, "config/app.yaml", "port: 9090")
+    })
+}
+
+func TestFileEqFS(t *testing.T) {
+    t.Run("equal", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        FileEqFS(tc, testFS, "config/db.yaml", "host: localhost\n")
+    })
+
+    t.Run("different", func(t *testing.T) {
+        tc := newCase(t, `expected equality of file content`)
+        t.Cleanup(tc.assert)
+
+        FileEqFS(tc, testFS, "config/db.yaml", "host: remote\n")
+    })
+}
+
+func TestDirContainsFilesFS(t *testing.T) {
+    t.Run("contains", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        DirContainsFilesFS(tc, testFS, "config", []string{"app.yaml", "extra/a"})
+    })
+
+    t.Run("missing", func(t *testing.T) {
+        tc := newCase(t, `missing: ["b.yaml"]`)
+        t.Cleanup(tc.assert)
+
+        DirContainsFilesFS(tc, testFS, "config", []string{"app.yaml", "b.yaml"})
+    })
+}
+
+func TestFile_os(t *testing.T) {
+    dir := t.TempDir()
+    file := filepath.Join(dir, "data.txt")
+    NoError(t, os.WriteFile(file, []byte("hello"), 0o640))
+    NoError(t, os.Symlink("data.txt", filepath.Join(dir, "link")))
+
+    FileExists(t, file)
+    DirExists(t, dir)
+    FileNotExists(t, filepath.Join(dir, "missing"))
+    FileMode(t, file, 0o640)
+    FileSize(t, file, 5)
+    FileContains(t, file, "ell")
+    FileEq(t, file, "hello")
+    DirContainsFiles(t, dir, []string{"data.txt", "link"})
+    FileSymlinkTo(t, filepath.Join(dir, "link"), "data.txt")
+
+    t.Run("not symlink", func(t *testing.T) {
+        tc := newCase(t, `expected file to be a symbolic link`)
+        t.Cleanup(tc.assert)
+
+        FileSymlinkTo(tc, file, "data.txt")
+    })
+
+    t.Run("wrong target", func(t *testing.T) {
+        tc := newCase(t, `target: "data.txt", expected: "other.txt"`)
+        t.Cleanup(tc.assert)
+
+        FileSymlinkTo(tc, filepath.Join(dir, "link"), "other.txt")
+    })
+
+    t.Run("unsupported", func(t *testing.T) {
+        tc := newCase(t, `expected file to be a symbolic link`)
+        t.Cleanup(tc.assert)
+
+        FileSymlinkToFS(tc, testFS, "config/db.yaml", "x")
+    })
+}
@@ -1,25 +1,79 @@
 package must
 
 import (
+    "reflect"
     "strings"
+    "sync"
         "github.com/ninepeach/go-test/assertions"
 )
 
+// helpers holds the functions marked via Helper for each test supporting
+// Cleanup, until the test finishes.
+var helpers sync.Map
+
+// marker is implemented by the T wrappers of this package, such as the B of
+// Batch, which hold the helper marks made on them themselves.
+type marker interface {
+    marks() *assertions.Helpers
+}
+
+// Helper marks the calling function as an assertion helper for t. Failures of
+// assertions made on t within it are then reported at the line calling into
+// the helper, which t.Helper alone cannot achieve for the output of this
+// package. The mark lasts until t finishes. It requires t to support Cleanup,
+// or to be a T given out by this package, such as the B of Batch; otherwise
+// Helper does nothing.
+func Helper(t T) {
+    t.Helper()
+    if m, ok := t.(marker); ok {
+        m.marks().Mark()
+        return
+    }
+    c, ok := t.(interface{ Cleanup(func()) })
+    if !ok || !reflect.TypeOf(t).Comparable() {
+        return
+    }
+    h, loaded := helpers.LoadOrStore(t, new(assertions.Helpers))
+    if !loaded {
+        c.Cleanup(func() { helpers.Delete(t) })
+    }
+    h.(*assertions.Helpers).Mark()
+}
+
+// helpersOf returns the functions marked via Helper for t.
+func helpersOf(t T) *assertions.Helpers {
+    if m, ok := t.(marker); ok {
+        return m.marks()
+    }
+    if !reflect.TypeOf(t).Comparable() {
+        return nil
+    }
+    if h, ok := helpers.Load(t); ok {
+        return h.(*assertions.Helpers)
+    }
+    return nil
+}
+
 func passing(result string) bool {
     return result == ""
 }
 
-func fail(t T, msg string) {
+func fail(t T, msg string, settings ...Setting) {
     t.Helper()
-    c := assertions.Caller()
-    s := c + msg + "\n" 
-    errorf(t, "\n"+strings.TrimSpace(s)+"\n")
+    s := apply(settings...)
+    h := helpersOf(t)
+    c := assertions.CallerFormat(s.pathFormat, h)
+    msg = c + bound(msg, s) + "\n"
+    if s.stackTrace {
+        msg += "↪ Stack trace ↷\n" + assertions.Stack(s.pathFormat, h)
+    }
+    errorf(t, "%s", "\n"+strings.TrimSpace(msg)+"\n")
 }
 
 func invoke(t T, result string, settings ...Setting) {
     t.Helper()
     result = strings.TrimSpace(result)
     if !passing(result) {
-        fail(t, result )
+        fail(t, result, settings...)
     }
 }
@@ -0,0 +1,101 @@
+package must
+
+import (
+    "fmt"
+    "path/filepath"
+    "runtime"
+    "strings"
+    "testing"
+
+    "github.com/ninepeach/go-test/musttest"
+)
+
+// checkPositive is a user-defined helper wrapping must assertions.
+func checkPositive(t T, n int) {
+    t.Helper()
+    Helper(t)
+    True(t, n > 0)
+}
+
+// checkPositiveOn marks itself as a helper for marked but asserts on t.
+func checkPositiveOn(marked, t T, n int) (line int) {
+    Helper(marked)
+    _, _, line, _ = runtime.Caller(0)
+    True(t, n > 0)
+    return line + 1
+}
+
+func TestCaller(t *testing.T) {
+    t.Run("base", func(t *testing.T) {
+        tc := newCase(t, `invocations_test.go:`)
+        t.Cleanup(tc.assert)
+
+        True(tc, false)
+    })
+
+    t.Run("helper", func(t *testing.T) {
+        rec := musttest.New(t)
+
+        _, _, line, _ := runtime.Caller(0)
+        checkPositive(rec, -1)
+        rec.ExpectFailure(fmt.Sprintf("invocations_test.go:%d:", line+1))
+
+        rec.RunCleanups()
+        Nil(t, helpersOf(rec))
+    })
+
+    t.Run("helper in batch", func(t *testing.T) {
+        rec := musttest.New(t)
+
+        var line int
+        Batch(rec, func(b B) {
+            _, _, line, _ = runtime.Caller(0)
+            checkPositive(b, -1)
+        })
+        rec.ExpectFailure(fmt.Sprintf("invocations_test.go:%d:", line+1))
+        _, stored := helpers.Load(rec)
+        False(t, stored)
+    })
+
+    t.Run("helper scoped to test", func(t *testing.T) {
+        marked := musttest.New(t)
+        tc := newCapture(t)
+        t.Cleanup(tc.assert)
+
+        line := checkPositiveOn(marked, tc, -1)
+        if exp := fmt.Sprintf("invocations_test.go:%d:", line); !strings.Contains(tc.capture, exp) {
+            t.Fatalf("expected helper marked for another test to be reported, got %q", tc.capture)
+        }
+    })
+
+    t.Run("full path", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assert)
+
+        True(tc, false, FullPath())
+        exp := filepath.Join("must", "invocations_test.go")
+        if !strings.Contains(tc.capture, exp) || !filepath.IsAbs(strings.SplitN(tc.capture, ":", 2)[0]) {
+            t.Fatalf("expected absolute path in output, got %q", tc.capture)
+        }
+    })
+
+    t.Run("module path", func(t *testing.T) {
+        tc := newCase(t, filepath.Join("must", "invocations_test.go")+":")
+        t.Cleanup(tc.assert)
+
+        True(tc, false, ModulePath())
+        if !strings.HasPrefix(tc.capture, "must") {
+            t.Fatalf("expected module relative path in output, got %q", tc.capture)
+        }
+    })
+
+    t.Run("stack trace", func(t *testing.T) {
+        tc := newCase(t, `↪ Stack trace ↷`)
+        t.Cleanup(tc.assert)
+
+        True(tc, false, StackTrace())
+        if !strings.Contains(tc.capture, "must.TestCaller.func7") {
+            t.Fatalf("expected test function in stack trace, got %q", tc.capture)
+        }
+    })
+}
@@ -0,0 +1,34 @@
+package must
+
+import (
+    "io"
+    "regexp"
+
+    "github.com/ninepeach/go-test/assertions"
+)
+
+// ReaderEq asserts exp and val produce the same bytes. Both are streamed chunk
+// by chunk, and the offset of the first differing byte is reported along with
+// a hex dump of the bytes around it.
+func ReaderEq(t T, exp, val io.Reader, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.ReaderEq(exp, val), settings...)
+}
+
+// ReaderContains asserts r produces sub somewhere in its output.
+func ReaderContains(t T, r io.Reader, sub string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.ReaderContains(r, sub), settings...)
+}
+
+// ReaderMatchesRegex asserts the output of r matches re.
+func ReaderMatchesRegex(t T, r io.Reader, re *regexp.Regexp, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.ReaderMatchesRegex(r, re), settings...)
+}
+
+// ReaderLen asserts r produces exactly n bytes.
+func ReaderLen(t T, n int64, r io.Reader, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.ReaderLen(n, r), settings...)
+}
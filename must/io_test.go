@@ -0,0 +1,91 @@
+package must
+
+import (
+    "bytes"
+    "io"
+    "regexp"
+    "strings"
+    "testing"
+)
+
+func TestReaderEq(t *testing.T) {
+    data := bytes.Repeat([]byte("abcdefghij"), 10_000)
+
+    t.Run("equal", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        ReaderEq(tc, bytes.NewReader(data), bytes.NewReader(data))
+    })
+
+    t.Run("difference", func(t *testing.T) {
+        tc := newCase(t, `first difference at offset 70000 (0x11170)`)
+        t.Cleanup(tc.assert)
+
+        other := bytes.Clone(data)
+        other[70000] = 'X'
+        ReaderEq(tc, bytes.NewReader(data), bytes.NewReader(other))
+
+        if !strings.Contains(tc.capture, "|Xbcdefghijabcdef|") {
+            t.Fatalf("expected window around difference, got %q", tc.capture)
+        }
+    })
+
+    t.Run("chunk boundary", func(t *testing.T) {
+        tc := newCase(t, `first difference at offset 65538 (0x10002)`)
+        t.Cleanup(tc.assert)
+
+        other := bytes.Clone(data)
+        other[65538] = 'X'
+        ReaderEq(tc, bytes.NewReader(data), bytes.NewReader(other))
+
+        if !strings.Contains(tc.capture, "0000fff2  ") {
+            t.Fatalf("expected window to include previous chunk, got %q", tc.capture)
+        }
+    })
+
+    t.Run("short", func(t *testing.T) {
+        tc := newCase(t, `value reader ended at offset 50000`)
+        t.Cleanup(tc.assert)
+
+        ReaderEq(tc, bytes.NewReader(data), bytes.NewReader(data[:50000]))
+    })
+
+    t.Run("long", func(t *testing.T) {
+        tc := newCase(t, `expected reader ended at offset 3`)
+        t.Cleanup(tc.assert)
+
+        ReaderEq(tc, strings.NewReader("abc"), strings.NewReader("abcd"))
+    })
+}
+
+func TestReaderContains(t *testing.T) {
+    t.Run("spanning chunks", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        r := io.MultiReader(strings.NewReader(strings.Repeat("x", 32*1024-2)), strings.NewReader("needle"))
+        ReaderContains(tc, r, "needle")
+    })
+
+    t.Run("missing", func(t *testing.T) {
+        tc := newCase(t, `expected reader to contain substring`)
+        t.Cleanup(tc.assert)
+
+        ReaderContains(tc, strings.NewReader("haystack"), "needle")
+    })
+}
+
+func TestReaderMatchesRegex(t *testing.T) {
+    tc := newCase(t, `expected reader to match regex`)
+    t.Cleanup(tc.assert)
+
+    ReaderMatchesRegex(tc, strings.NewReader("hello world"), regexp.MustCompile(`^world`))
+}
+
+func TestReaderLen(t *testing.T) {
+    tc := newCase(t, `len(reader): 5, expected: 4`)
+    t.Cleanup(tc.assert)
+
+    ReaderLen(tc, 4, strings.NewReader("hello"))
+}
@@ -8,6 +8,14 @@ import (
 // ErrorAssertionFunc allows passing Error and NoError in table driven tests
 type ErrorAssertionFunc func(t T, err error, settings ...Setting)
 
+// ValueAssertionFunc allows passing assertions over a single value, such as
+// NotNil, in table driven tests.
+type ValueAssertionFunc[A any] func(t T, val A, settings ...Setting)
+
+// ComparisonAssertionFunc allows passing assertions comparing an expected and
+// actual value, such as Eq and NotEq, in table driven tests.
+type ComparisonAssertionFunc[A any] func(t T, exp, val A, settings ...Setting)
+
 // Nil asserts a is nil.
 func Nil(t T, a any, settings ...Setting) {
     t.Helper()
@@ -36,13 +44,13 @@ func False(t T, condition bool, settings ...Setting) {
 // Zero asserts n == 0.
 func Zero[N interfaces.Number](t T, n N, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.Zero(n), settings...)
+    invoke(t, assertions.Zero(n, printOptions(settings...)...), settings...)
 }
 
 // NonZero asserts n != 0.
 func NonZero[N interfaces.Number](t T, n N, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.NonZero(n), settings...)
+    invoke(t, assertions.NonZero(n, printOptions(settings...)...), settings...)
 }
 
 // Unreachable asserts a code path is not executed.
@@ -51,12 +59,31 @@ func Unreachable(t T, settings ...Setting) {
     invoke(t, assertions.Unreachable(), settings...)
 }
 
+// Check asserts a passes. It allows user-defined assertions to be invoked with
+// the same caller reporting, settings and formatting as the built-in ones.
+func Check(t T, a assertions.Assertion, settings ...Setting) {
+    t.Helper()
+    invoke(t, a(), settings...)
+}
+
+// That asserts val is matched by m.
+func That[A any](t T, val A, m interfaces.Matcher[A], settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.That(val, m, printOptions(settings...)...), settings...)
+}
+
 // Error asserts err is a non-nil error.
 func Error(t T, err error, settings ...Setting) {
     t.Helper()
     invoke(t, assertions.Error(err), settings...)
 }
 
+// NoError asserts err is a nil error.
+func NoError(t T, err error, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.NoError(err), settings...)
+}
+
 // Eq asserts exp and val are equal using cmp.Equal.
 func Eq[A any](t T, exp, val A, settings ...Setting) {
     t.Helper()
@@ -172,25 +199,25 @@ func MapNotEmpty[M ~map[K]V, K comparable, V any](t T, m M, settings ...Setting)
 // MapContainsKey asserts m contains key.
 func MapContainsKey[M ~map[K]V, K comparable, V any](t T, m M, key K, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.MapContainsKey(m, key), settings...)
+    invoke(t, assertions.MapContainsKey(m, key, printOptions(settings...)...), settings...)
 }
 
 // MapNotContainsKey asserts m does not contain key.
 func MapNotContainsKey[M ~map[K]V, K comparable, V any](t T, m M, key K, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.MapNotContainsKey(m, key), settings...)
+    invoke(t, assertions.MapNotContainsKey(m, key, printOptions(settings...)...), settings...)
 }
 
 // MapContainsKeys asserts m contains each key in keys.
 func MapContainsKeys[M ~map[K]V, K comparable, V any](t T, m M, keys []K, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.MapContainsKeys(m, keys), settings...)
+    invoke(t, assertions.MapContainsKeys(m, keys, printOptions(settings...)...), settings...)
 }
 
 // MapNotContainsKeys asserts m does not contain any key in keys.
 func MapNotContainsKeys[M ~map[K]V, K comparable, V any](t T, m M, keys []K, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.MapNotContainsKeys(m, keys), settings...)
+    invoke(t, assertions.MapNotContainsKeys(m, keys, printOptions(settings...)...), settings...)
 }
 
 // MapContainsValues asserts m contains each val in vals.
@@ -208,25 +235,25 @@ func MapNotContainsValues[M ~map[K]V, K comparable, V any](t T, m M, vals []V, s
 // MapContainsValuesFunc asserts m contains each val in vals using the eq function.
 func MapContainsValuesFunc[M ~map[K]V, K comparable, V any](t T, m M, vals []V, eq func(V, V) bool, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.MapContainsValuesFunc(m, vals, eq), settings...)
+    invoke(t, assertions.MapContainsValuesFunc(m, vals, eq, printOptions(settings...)...), settings...)
 }
 
 // MapNotContainsValuesFunc asserts m does not contain any value in vals using the eq function.
 func MapNotContainsValuesFunc[M ~map[K]V, K comparable, V any](t T, m M, vals []V, eq func(V, V) bool, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.MapNotContainsValuesFunc(m, vals, eq), settings...)
+    invoke(t, assertions.MapNotContainsValuesFunc(m, vals, eq, printOptions(settings...)...), settings...)
 }
 
 // MapContainsValuesEqual asserts m contains each val in vals using the V.Equal method.
 func MapContainsValuesEqual[M ~map[K]V, K comparable, V interfaces.EqualFunc[V]](t T, m M, vals []V, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.MapContainsValuesEqual(m, vals), settings...)
+    invoke(t, assertions.MapContainsValuesEqual(m, vals, printOptions(settings...)...), settings...)
 }
 
 // MapNotContainsValuesEqual asserts m does not contain any value in vals using the V.Equal method.
 func MapNotContainsValuesEqual[M ~map[K]V, K comparable, V interfaces.EqualFunc[V]](t T, m M, vals []V, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.MapNotContainsValuesEqual(m, vals), settings...)
+    invoke(t, assertions.MapNotContainsValuesEqual(m, vals, printOptions(settings...)...), settings...)
 }
 
 // MapContainsValue asserts m contains val.
@@ -244,25 +271,25 @@ func MapNotContainsValue[M ~map[K]V, K comparable, V any](t T, m M, val V, setti
 // MapContainsValueFunc asserts m contains val using the eq function.
 func MapContainsValueFunc[M ~map[K]V, K comparable, V any](t T, m M, val V, eq func(V, V) bool, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.MapContainsValueFunc(m, val, eq), settings...)
+    invoke(t, assertions.MapContainsValueFunc(m, val, eq, printOptions(settings...)...), settings...)
 }
 
 // MapNotContainsValueFunc asserts m does not contain val using the eq function.
 func MapNotContainsValueFunc[M ~map[K]V, K comparable, V any](t T, m M, val V, eq func(V, V) bool, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.MapNotContainsValueFunc(m, val, eq), settings...)
+    invoke(t, assertions.MapNotContainsValueFunc(m, val, eq, printOptions(settings...)...), settings...)
 }
 
 // MapContainsValueEqual asserts m contains val using the V.Equal method.
 func MapContainsValueEqual[M ~map[K]V, K comparable, V interfaces.EqualFunc[V]](t T, m M, val V, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.MapContainsValueEqual(m, val), settings...)
+    invoke(t, assertions.MapContainsValueEqual(m, val, printOptions(settings...)...), settings...)
 }
 
 // MapNotContainsValueEqual asserts m does not contain val using the V.Equal method.
 func MapNotContainsValueEqual[M ~map[K]V, K comparable, V interfaces.EqualFunc[V]](t T, m M, val V, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.MapNotContainsValueEqual(m, val), settings...)
+    invoke(t, assertions.MapNotContainsValueEqual(m, val, printOptions(settings...)...), settings...)
 }
 
 // SliceEqFunc asserts elements of val satisfy eq for the corresponding element in exp.
@@ -313,19 +340,19 @@ func Len[A any](t T, n int, slice []A, settings ...Setting) {
 // SliceContainsOp asserts item exists in slice using == operator.
 func SliceContainsOp[C comparable](t T, slice []C, item C, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.SliceContainsOp(slice, item), settings...)
+    invoke(t, assertions.SliceContainsOp(slice, item, printOptions(settings...)...), settings...)
 }
 
 // SliceContainsFunc asserts item exists in slice, using eq to compare elements.
 func SliceContainsFunc[A, B any](t T, slice []A, item B, eq func(a A, b B) bool, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.SliceContainsFunc(slice, item, eq), settings...)
+    invoke(t, assertions.SliceContainsFunc(slice, item, eq, printOptions(settings...)...), settings...)
 }
 
 // SliceContainsEqual asserts item exists in slice, using Equal to compare elements.
 func SliceContainsEqual[E interfaces.EqualFunc[E]](t T, slice []E, item E, settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.SliceContainsEqual(slice, item), settings...)
+    invoke(t, assertions.SliceContainsEqual(slice, item, printOptions(settings...)...), settings...)
 }
 
 // SliceContains asserts item exists in slice, using cmp.Equal to compare elements.
@@ -375,7 +402,7 @@ func Contains[C any](t T, element C, container interfaces.ContainsFunc[C], setti
 // There may be elements in container beyond what is present in elements.
 func ContainsSubset[C any](t T, elements []C, container interfaces.ContainsFunc[C], settings ...Setting) {
     t.Helper()
-    invoke(t, assertions.ContainsSubset(elements, container), settings...)
+    invoke(t, assertions.ContainsSubset(elements, container, printOptions(settings...)...), settings...)
 }
 
 // NotContains asserts container.ContainsFunc(element) is false.
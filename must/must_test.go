@@ -1,8 +1,12 @@
 package must
 
 import (
+    "fmt"
     "testing"
     "time"
+
+    "github.com/ninepeach/go-test/assertions"
+    "github.com/ninepeach/go-test/matchers"
 )
 
 func TestNil(t *testing.T) {
@@ -606,6 +610,26 @@ func TestMapContainsKey(t *testing.T) {
     MapContainsKey(tc, m, "c")
 }
 
+type weekday int
+
+func (d weekday) String() string {
+    return [...]string{"Sunday", "Monday"}[d]
+}
+
+func TestMapContainsKey_stringers(t *testing.T) {
+    t.Run("default", func(t *testing.T) {
+        tc := newCase(t, `↪key: 1`)
+        t.Cleanup(tc.assert)
+        MapContainsKey(tc, map[weekday]int{0: 1}, weekday(1))
+    })
+
+    t.Run("stringers", func(t *testing.T) {
+        tc := newCase(t, `↪key: Monday`)
+        t.Cleanup(tc.assert)
+        MapContainsKey(tc, map[weekday]int{0: 1}, weekday(1), Stringers())
+    })
+}
+
 func TestMapNotContainsKey(t *testing.T) {
     tc := newCase(t, `expected map to not contain key`)
     t.Cleanup(tc.assert)
@@ -754,3 +778,70 @@ func TestMapNotContainsValueEqual(t *testing.T) {
 }
 
 
+
+func TestCheck(t *testing.T) {
+    even := func(n int) assertions.Assertion {
+        return func() (s string) {
+            if n%2 != 0 {
+                s = fmt.Sprintf("expected even number; got %d\n", n)
+            }
+            return
+        }
+    }
+
+    t.Run("custom", func(t *testing.T) {
+        tc := newCase(t, `expected even number; got 3`)
+        t.Cleanup(tc.assert)
+
+        Check(tc, even(3))
+    })
+
+    t.Run("passing", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        Check(tc, assertions.All(even(2), assertions.Not(even(3)), assertions.Any(even(1), even(4))))
+    })
+
+    t.Run("not", func(t *testing.T) {
+        tc := newCase(t, `expected assertion to fail; it passed`)
+        t.Cleanup(tc.assert)
+
+        Check(tc, assertions.Not(even(2)))
+    })
+
+    t.Run("all", func(t *testing.T) {
+        tc := newCase(t, `expected all assertions to pass; 2 of 3 failed`)
+        t.Cleanup(tc.assert)
+
+        Check(tc, assertions.All(even(1), even(2), even(5)))
+    })
+
+    t.Run("any", func(t *testing.T) {
+        tc := newCase(t, `expected any assertion to pass; all 2 failed`)
+        t.Cleanup(tc.assert)
+
+        Check(tc, assertions.Any(even(1), even(3)))
+    })
+
+    t.Run("all empty", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        Check(tc, assertions.All())
+    })
+
+    t.Run("any empty", func(t *testing.T) {
+        tc := newCase(t, `expected any assertion to pass; none were given`)
+        t.Cleanup(tc.assert)
+
+        Check(tc, assertions.Any())
+    })
+}
+
+func TestThat(t *testing.T) {
+    tc := newCase(t, `expected value to match`)
+    t.Cleanup(tc.assert)
+
+    That(tc, 3, matchers.EqualTo(4))
+}
@@ -0,0 +1,155 @@
+package must
+
+import (
+    "bytes"
+    "fmt"
+    "io"
+    "os"
+    "sync/atomic"
+    "time"
+
+    "github.com/ninepeach/go-test/assertions"
+)
+
+// drainTimeout bounds how long CaptureOutput waits for output to be drained
+// once the function has returned, in case a process started by the function
+// still holds the write end of a pipe.
+const drainTimeout = 500 * time.Millisecond
+
+// capturing guards against concurrent use of CaptureOutput, since file
+// descriptors 1 and 2 are process wide. It catches overlapping captures made
+// with a T which cannot tell whether its test is parallel.
+var capturing atomic.Bool
+
+// captureEnv is set, via Setenv, for the remainder of a test using
+// CaptureOutput.
+const captureEnv = "GO_TEST_CAPTURE_OUTPUT"
+
+// Output is the text written to standard output and standard error, captured
+// by CaptureOutput.
+type Output struct {
+    Stdout string
+    Stderr string
+}
+
+// pipe is a redirection of a file descriptor through an os.Pipe.
+type pipe struct {
+    undo func() error
+    r, w *os.File
+    buf  bytes.Buffer
+    done chan struct{}
+}
+
+func redirect(fd int) (*pipe, error) {
+    r, w, err := os.Pipe()
+    if err != nil {
+        return nil, err
+    }
+    undo, err := redirectFD(fd, w)
+    if err != nil {
+        _ = r.Close()
+        _ = w.Close()
+        return nil, err
+    }
+    p := &pipe{undo: undo, r: r, w: w, done: make(chan struct{})}
+    go func() {
+        defer close(p.done)
+        _, _ = io.Copy(&p.buf, r)
+    }()
+    return p, nil
+}
+
+// restore points the file descriptor back at the original file and returns
+// everything written to the pipe. Writes after restore go to the original
+// file.
+func (p *pipe) restore() string {
+    _ = p.undo()
+    _ = p.w.Close()
+    select {
+    case <-p.done:
+    case <-time.After(drainTimeout):
+        _ = p.r.Close()
+        <-p.done
+    }
+    _ = p.r.Close()
+    return p.buf.String()
+}
+
+// CaptureOutput runs f with standard output and standard error redirected
+// through pipes at the file descriptor level, restoring them once f returns,
+// and returns what was written. The os.Stdout and os.Stderr variables are left
+// alone, so goroutines started by f may keep writing after it returns; their
+// output then goes to the original destination and is not captured.
+//
+// CaptureOutput is not for parallel tests: the redirection is process wide, so
+// output written by other tests while f runs is captured too, and theirs would
+// be lost. If t supports Setenv, CaptureOutput calls it, which fails the test
+// if it is parallel and keeps it from calling t.Parallel afterwards. Otherwise
+// it fails the test if another capture is already in progress.
+//
+// With go test -v, log lines written by t.Log and friends while f runs are
+// streamed to standard output, and so are captured as well.
+func CaptureOutput(t T, f func()) Output {
+    t.Helper()
+    if msg := notParallel(t); msg != "" {
+        invoke(t, msg)
+        return Output{}
+    }
+    if !capturing.CompareAndSwap(false, true) {
+        invoke(t, "CaptureOutput cannot be used concurrently; is the test running in parallel?")
+        return Output{}
+    }
+    defer capturing.Store(false)
+
+    stdout, err := redirect(1)
+    if err != nil {
+        invoke(t, fmt.Sprintf("failed to redirect stdout: %v", err))
+        return Output{}
+    }
+    stderr, err := redirect(2)
+    if err != nil {
+        stdout.restore()
+        invoke(t, fmt.Sprintf("failed to redirect stderr: %v", err))
+        return Output{}
+    }
+
+    var output Output
+    func() {
+        defer func() {
+            output.Stdout = stdout.restore()
+            output.Stderr = stderr.restore()
+        }()
+        f()
+    }()
+    return output
+}
+
+// notParallel marks t as unable to run in parallel via its Setenv method, if
+// it has one, and describes why CaptureOutput cannot be used if the test is
+// already parallel.
+func notParallel(t T) (msg string) {
+    s, ok := t.(interface{ Setenv(key, value string) })
+    if !ok {
+        return ""
+    }
+    defer func() {
+        if r := recover(); r != nil {
+            msg = fmt.Sprintf("CaptureOutput cannot be used in parallel tests\n↪ %v", r)
+        }
+    }()
+    s.Setenv(captureEnv, "1")
+    return ""
+}
+
+// OutputLines asserts output consists of exactly the lines exp, ignoring a
+// trailing newline.
+func OutputLines(t T, exp []string, output string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.OutputLines(exp, output), settings...)
+}
+
+// OutputContains asserts output contains sub.
+func OutputContains(t T, output, sub string, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.OutputContains(output, sub), settings...)
+}
@@ -0,0 +1,25 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package must
+
+import (
+    "os"
+    "syscall"
+)
+
+// redirectFD points fd at w, returning a function pointing it back at the
+// original file.
+func redirectFD(fd int, w *os.File) (func() error, error) {
+    original, err := syscall.Dup(fd)
+    if err != nil {
+        return nil, err
+    }
+    if err := syscall.Dup2(int(w.Fd()), fd); err != nil {
+        _ = syscall.Close(original)
+        return nil, err
+    }
+    return func() error {
+        defer syscall.Close(original)
+        return syscall.Dup2(original, fd)
+    }, nil
+}
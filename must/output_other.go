@@ -0,0 +1,13 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package must
+
+import (
+    "errors"
+    "os"
+)
+
+// redirectFD is unsupported on platforms without dup2.
+func redirectFD(int, *os.File) (func() error, error) {
+    return nil, errors.ErrUnsupported
+}
@@ -0,0 +1,92 @@
+package must
+
+import (
+    "fmt"
+    "os"
+    "testing"
+    "time"
+
+    "github.com/ninepeach/go-test/musttest"
+)
+
+func TestCaptureOutput(t *testing.T) {
+    out := CaptureOutput(t, func() {
+        fmt.Println("hello")
+        fmt.Println("world")
+        fmt.Fprintln(os.Stderr, "oops")
+    })
+
+    OutputLines(t, []string{"hello", "world"}, out.Stdout)
+    OutputContains(t, out.Stderr, "oops")
+}
+
+func TestCaptureOutput_lateWriter(t *testing.T) {
+    written := make(chan error)
+    out := CaptureOutput(t, func() {
+        fmt.Println("early")
+        go func() {
+            time.Sleep(10 * time.Millisecond)
+            _, err := fmt.Println("late")
+            written <- err
+        }()
+    })
+
+    NoError(t, <-written)
+    OutputLines(t, []string{"early"}, out.Stdout)
+}
+
+func TestCaptureOutput_concurrent(t *testing.T) {
+    tc := newCase(t, `CaptureOutput cannot be used concurrently`)
+    t.Cleanup(tc.assert)
+
+    CaptureOutput(t, func() {
+        CaptureOutput(tc, func() {})
+    })
+}
+
+// setenvT records failures, but sets environment variables on a real test.
+type setenvT struct {
+    *musttest.T
+    t *testing.T
+}
+
+func (s setenvT) Setenv(key, value string) {
+    s.t.Setenv(key, value)
+}
+
+func TestCaptureOutput_parallel(t *testing.T) {
+    t.Run("serial", func(t *testing.T) {
+        rec := musttest.New(t)
+        out := CaptureOutput(setenvT{T: rec, t: t}, func() {
+            fmt.Println("hello")
+        })
+        rec.ExpectPass()
+        OutputLines(t, []string{"hello"}, out.Stdout)
+        Eq(t, "1", os.Getenv(captureEnv))
+    })
+
+    t.Run("parallel", func(t *testing.T) {
+        t.Parallel()
+        rec := musttest.New(t)
+        called := false
+        CaptureOutput(setenvT{T: rec, t: t}, func() {
+            called = true
+        })
+        rec.ExpectFailure("CaptureOutput cannot be used in parallel tests")
+        False(t, called)
+    })
+}
+
+func TestOutputLines(t *testing.T) {
+    tc := newCase(t, `expected output lines to match`)
+    t.Cleanup(tc.assert)
+
+    OutputLines(tc, []string{"a", "b"}, "a\nc\n")
+}
+
+func TestOutputContains(t *testing.T) {
+    tc := newCase(t, `expected output to contain substring`)
+    t.Cleanup(tc.assert)
+
+    OutputContains(tc, "hello", "world")
+}
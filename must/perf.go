@@ -0,0 +1,52 @@
+package must
+
+import (
+    "testing"
+    "time"
+
+    "github.com/ninepeach/go-test/assertions"
+    "github.com/ninepeach/go-test/internal/race"
+)
+
+// AllocsAtMost asserts f makes at most n heap allocations per run, averaged
+// over many runs using testing.AllocsPerRun.
+func AllocsAtMost(t T, n float64, f func(), settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.AllocsAtMost(n, f), settings...)
+}
+
+// BytesAllocatedAtMost asserts f allocates at most n bytes of heap memory per
+// run, averaged over many runs. The count comes from the process-wide
+// runtime.MemStats, so allocations of tests running in parallel are included;
+// call it only from tests which are not parallel.
+func BytesAllocatedAtMost(t T, n uint64, f func(), settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.BytesAllocatedAtMost(n, f), settings...)
+}
+
+// FasterThan asserts the median duration of runs of f, following a warm-up
+// run, is within budget.
+//
+// Timing is unreliable under the race detector and not worth the wait in short
+// mode, so in those cases the assertion is skipped if t supports skipping.
+// Otherwise it passes, logging why if t supports logging.
+func FasterThan(t T, budget time.Duration, f func(), runs int, settings ...Setting) {
+    t.Helper()
+    reason := ""
+    switch {
+    case race.Enabled:
+        reason = "race detector is enabled"
+    case testing.Short():
+        reason = "running in short mode"
+    }
+    if reason != "" {
+        switch t := t.(type) {
+        case interface{ Skipf(string, ...any) }:
+            t.Skipf("skipping timing assertion: %s", reason)
+        case interface{ Logf(string, ...any) }:
+            t.Logf("skipping timing assertion: %s", reason)
+        }
+        return
+    }
+    invoke(t, assertions.FasterThan(budget, f, runs), settings...)
+}
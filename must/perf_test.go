@@ -0,0 +1,74 @@
+package must
+
+import (
+    "testing"
+    "time"
+
+    "github.com/ninepeach/go-test/internal/race"
+    "github.com/ninepeach/go-test/musttest"
+)
+
+var sink []byte
+
+func TestAllocsAtMost(t *testing.T) {
+    t.Run("pass", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        AllocsAtMost(tc, 0, func() {})
+    })
+
+    t.Run("fail", func(t *testing.T) {
+        tc := newCase(t, `expected fewer allocations per run`)
+        t.Cleanup(tc.assert)
+
+        AllocsAtMost(tc, 0, func() { sink = make([]byte, 64) })
+    })
+}
+
+func TestBytesAllocatedAtMost(t *testing.T) {
+    t.Run("pass", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        BytesAllocatedAtMost(tc, 0, func() {})
+    })
+
+    t.Run("fail", func(t *testing.T) {
+        tc := newCase(t, `expected fewer bytes allocated per run`)
+        t.Cleanup(tc.assert)
+
+        BytesAllocatedAtMost(tc, 512, func() { sink = make([]byte, 4096) })
+    })
+}
+
+func TestFasterThan_skipped(t *testing.T) {
+    if !race.Enabled && !testing.Short() {
+        t.Skip("timing assertions are not skipped")
+    }
+
+    rec := musttest.New(t)
+    FasterThan(rec, time.Microsecond, func() { time.Sleep(time.Millisecond) }, 3)
+    rec.ExpectPass()
+    rec.ExpectLog("skipping timing assertion")
+}
+
+func TestFasterThan(t *testing.T) {
+    if race.Enabled || testing.Short() {
+        t.Skip("timing assertions are skipped")
+    }
+
+    t.Run("pass", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        FasterThan(tc, time.Second, func() {}, 5)
+    })
+
+    t.Run("fail", func(t *testing.T) {
+        tc := newCase(t, `expected function to complete within budget`)
+        t.Cleanup(tc.assert)
+
+        FasterThan(tc, time.Microsecond, func() { time.Sleep(time.Millisecond) }, 3)
+    })
+}
@@ -0,0 +1,56 @@
+package must
+
+import (
+    "errors"
+    "fmt"
+
+    "github.com/ninepeach/go-test/portal"
+)
+
+// FreePorts reserves n free TCP ports on 127.0.0.1, failing the test if not
+// enough ports are left. Ports are reserved across test processes and released
+// when the test completes, if t supports cleanup. Use PortRange to choose the
+// ports to allocate from.
+func FreePorts(t T, n int, settings ...Setting) []int {
+    t.Helper()
+    return take(t, portal.TCP, n, settings...)
+}
+
+// FreePort reserves a single free TCP port on 127.0.0.1, like FreePorts.
+func FreePort(t T, settings ...Setting) int {
+    t.Helper()
+    ports := take(t, portal.TCP, 1, settings...)
+    if len(ports) == 0 {
+        return 0
+    }
+    return ports[0]
+}
+
+// FreeUDPPorts reserves n free UDP ports on 127.0.0.1, like FreePorts.
+func FreeUDPPorts(t T, n int, settings ...Setting) []int {
+    t.Helper()
+    return take(t, portal.UDP, n, settings...)
+}
+
+func take(t T, network portal.Network, n int, settings ...Setting) []int {
+    t.Helper()
+    options := []portal.Setting{portal.CrossProcess()}
+    if s := apply(settings...); s.portMax > 0 {
+        options = append(options, portal.Range(s.portMin, s.portMax))
+    }
+    ports, err := portal.Take(network, n, options...)
+    if err != nil {
+        result := "failed to reserve ports\n"
+        if errors.Is(err, portal.ErrNoPorts) {
+            result = "no free ports left\n"
+        }
+        result += fmt.Sprintf("↪ requested: %d %s port(s)\n", n, network)
+        result += fmt.Sprintf("↪     error: %v\n", err)
+        invoke(t, result, settings...)
+        return nil
+    }
+    if c, ok := t.(interface{ Cleanup(func()) }); ok {
+        c.Cleanup(func() { portal.Release(network, ports...) })
+    }
+    return ports
+}
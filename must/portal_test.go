@@ -0,0 +1,37 @@
+package must
+
+import (
+    "testing"
+
+    "github.com/ninepeach/go-test/musttest"
+)
+
+func TestFreePorts(t *testing.T) {
+    ports := FreePorts(t, 3)
+    SliceLen(t, 3, ports)
+    NotEqOp(t, 0, FreePort(t))
+    SliceLen(t, 1, FreeUDPPorts(t, 1))
+}
+
+func TestFreePorts_exhausted(t *testing.T) {
+    port := FreePort(t)
+
+    tc := newCase(t, `no free ports left`)
+    t.Cleanup(tc.assert)
+
+    FreePorts(tc, 1, PortRange(port, port))
+}
+
+func TestFreePorts_released(t *testing.T) {
+    rec := musttest.New(t)
+    port := FreePort(rec)
+    rec.RunCleanups()
+    SliceEqOp(t, []int{port}, FreePorts(t, 1, PortRange(port, port)))
+}
+
+func TestFreePorts_invalid(t *testing.T) {
+    tc := newCase(t, "failed to reserve ports\n↪ requested: -1 tcp port(s)\n↪     error: portal: invalid number of ports -1")
+    t.Cleanup(tc.assert)
+
+    FreePorts(tc, -1)
+}
@@ -2,11 +2,24 @@ package must
 
 import (
     "github.com/google/go-cmp/cmp"
+    "github.com/ninepeach/go-test/assertions"
 )
 
-// Settings holds cmp.Options to customize test assertions.
+// Settings customizes test assertions: how values are compared and rendered,
+// how failures are reported, and the resources assertions may use.
 type Settings struct {
     cmpOptions []cmp.Option
+    pathFormat assertions.PathFormat
+    stackTrace bool
+    stringers  bool
+
+    maxDiffLines int
+    maxValueLen  int
+    diffFile     bool
+
+    treeOptions assertions.TreeOptions
+
+    portMin, portMax int
 }
 
 // Setting modifies the Settings configuration.
@@ -19,11 +32,114 @@ func Cmp(options ...cmp.Option) Setting {
     }
 }
 
-// options aggregates and returns all cmp.Options from the settings.
-func options(settings ...Setting) []cmp.Option {
+// Stringers renders values implementing fmt.Stringer using their String method
+// where assertions print individual values, such as a missing map key or slice
+// item. It has no effect on the cmp.Diff output of comparisons, nor on the
+// values printed by assertions taking cmp options. Values implementing
+// fmt.GoStringer are always rendered using GoString.
+func Stringers() Setting {
+    return func(s *Settings) {
+        s.stringers = true
+    }
+}
+
+// FullPath reports the caller of a failed assertion using its absolute file path.
+func FullPath() Setting {
+    return func(s *Settings) {
+        s.pathFormat = assertions.FullPath
+    }
+}
+
+// ModulePath reports the caller of a failed assertion using its file path
+// relative to the root of the enclosing Go module.
+func ModulePath() Setting {
+    return func(s *Settings) {
+        s.pathFormat = assertions.ModulePath
+    }
+}
+
+// StackTrace includes the complete call stack leading to a failed assertion in
+// the failure output.
+func StackTrace() Setting {
+    return func(s *Settings) {
+        s.stackTrace = true
+    }
+}
+
+// MaxDiffLines limits the failure output of an assertion to n lines. Changed
+// lines of a diff are kept along with some context, and the number of elided
+// lines is noted.
+func MaxDiffLines(n int) Setting {
+    return func(s *Settings) {
+        s.maxDiffLines = n
+    }
+}
+
+// MaxValueLen limits each line of the failure output of an assertion to n
+// characters, noting how many characters were elided.
+func MaxValueLen(n int) Setting {
+    return func(s *Settings) {
+        s.maxValueLen = n
+    }
+}
+
+// DiffFile writes the complete failure output of an assertion to a temporary
+// file, reporting only the first line of the output and the path of the file.
+func DiffFile() Setting {
+    return func(s *Settings) {
+        s.diffFile = true
+    }
+}
+
+// IgnorePaths leaves paths matching any of the path.Match patterns out of the
+// comparison made by DirTreeEq. A pattern matches either the slash separated
+// path relative to the root of the tree or its base name.
+func IgnorePaths(patterns ...string) Setting {
+    return func(s *Settings) {
+        s.treeOptions.Ignore = append(s.treeOptions.Ignore, patterns...)
+    }
+}
+
+// NormalizeLineEndings treats "\r\n" as "\n" when DirTreeEq compares file
+// content.
+func NormalizeLineEndings() Setting {
+    return func(s *Settings) {
+        s.treeOptions.NormalizeLineEndings = true
+    }
+}
+
+// IgnoreModes leaves file permissions out of the comparison made by DirTreeEq.
+func IgnoreModes() Setting {
+    return func(s *Settings) {
+        s.treeOptions.IgnoreModes = true
+    }
+}
+
+// PortRange sets the inclusive range FreePorts, FreePort and FreeUDPPorts
+// allocate ports from.
+func PortRange(min, max int) Setting {
+    return func(s *Settings) {
+        s.portMin, s.portMax = min, max
+    }
+}
+
+// apply aggregates the settings into a Settings configuration.
+func apply(settings ...Setting) *Settings {
     s := new(Settings)
     for _, setting := range settings {
         setting(s)
     }
-    return s.cmpOptions
-}
\ No newline at end of file
+    return s
+}
+
+// options aggregates and returns all cmp.Options from the settings.
+func options(settings ...Setting) []cmp.Option {
+    return apply(settings...).cmpOptions
+}
+
+func printOptions(settings ...Setting) []assertions.PrintOption {
+    if apply(settings...).stringers {
+        return []assertions.PrintOption{assertions.Stringers()}
+    }
+    return nil
+}
@@ -0,0 +1,163 @@
+package must
+
+import (
+    "context"
+    "log/slog"
+    "sync"
+
+    "github.com/ninepeach/go-test/assertions"
+)
+
+// LogEntry is a log record captured by a LogRecorder.
+type LogEntry = assertions.LogEntry
+
+// LogMatch describes a log entry expected by the log assertions.
+type LogMatch = assertions.LogMatch
+
+// LogRecorder records the entries written to a logger created by CaptureSlog.
+// It is safe for concurrent use.
+type LogRecorder struct {
+    lock    sync.Mutex
+    entries []LogEntry
+}
+
+// Entries returns the entries recorded so far.
+func (r *LogRecorder) Entries() []LogEntry {
+    r.lock.Lock()
+    defer r.lock.Unlock()
+    return append([]LogEntry(nil), r.entries...)
+}
+
+func (r *LogRecorder) record(e LogEntry) {
+    r.lock.Lock()
+    defer r.lock.Unlock()
+    r.entries = append(r.entries, e)
+}
+
+// captureHandler is a slog.Handler recording entries of every level.
+type captureHandler struct {
+    recorder *LogRecorder
+    attrs    []slog.Attr
+    prefix   string
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool {
+    return true
+}
+
+func (h *captureHandler) Handle(_ context.Context, r slog.Record) error {
+    var attrs []slog.Attr
+    r.Attrs(func(a slog.Attr) bool {
+        attrs = append(attrs, a)
+        return true
+    })
+    h.recorder.record(LogEntry{
+        Time:    r.Time,
+        Level:   r.Level,
+        Message: r.Message,
+        Attrs:   append(append([]slog.Attr(nil), h.attrs...), assertions.FlattenAttrs(h.prefix, attrs)...),
+    })
+    return nil
+}
+
+func (h *captureHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+    c := *h
+    c.attrs = append(append([]slog.Attr(nil), h.attrs...), assertions.FlattenAttrs(h.prefix, attrs)...)
+    return &c
+}
+
+func (h *captureHandler) WithGroup(name string) slog.Handler {
+    if name == "" {
+        return h
+    }
+    c := *h
+    c.prefix = h.prefix + name + "."
+    return &c
+}
+
+// CaptureSlog creates a logger recording every entry written to it, at any
+// level, along with the recorder to assert on.
+func CaptureSlog(t T) (*slog.Logger, *LogRecorder) {
+    t.Helper()
+    r := new(LogRecorder)
+    return slog.New(&captureHandler{recorder: r}), r
+}
+
+// logMatch builds the LogMatch described by level, msg and args, where args
+// are attributes given as to slog.Logger.Log, in key-value pairs or as
+// slog.Attr values. Any Setting among args is returned separately.
+func logMatch(level slog.Level, msg string, args []any) (LogMatch, []Setting) {
+    var (
+        settings []Setting
+        rest     []any
+    )
+    for _, arg := range args {
+        if setting, ok := arg.(Setting); ok {
+            settings = append(settings, setting)
+            continue
+        }
+        rest = append(rest, arg)
+    }
+    var record slog.Record
+    record.Add(rest...)
+    m := LogMatch{Level: level, Message: msg}
+    record.Attrs(func(a slog.Attr) bool {
+        m.Attrs = append(m.Attrs, a)
+        return true
+    })
+    return m, settings
+}
+
+// LogContains asserts r recorded an entry of level, with a message matching
+// the regular expression msg, and with the attributes of args. Attributes are
+// given as to slog.Logger.Log, and settings may be mixed in among them.
+// Attribute values are compared with cmp.Equal, using the options of any Cmp
+// settings.
+func LogContains(t T, r *LogRecorder, level slog.Level, msg string, args ...any) {
+    t.Helper()
+    m, settings := logMatch(level, msg, args)
+    LogContainsMatch(t, r, m, settings...)
+}
+
+// LogContainsMatch asserts r recorded an entry satisfying m. It is the form of
+// LogContains for matches built ahead of time, such as in test tables.
+func LogContainsMatch(t T, r *LogRecorder, m LogMatch, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.LogContains(r.Entries(), m, options(settings...)), settings...)
+}
+
+// LogNotContains asserts r recorded no entry of level, with a message matching
+// msg, and with the attributes of args. Arguments are as for LogContains.
+func LogNotContains(t T, r *LogRecorder, level slog.Level, msg string, args ...any) {
+    t.Helper()
+    m, settings := logMatch(level, msg, args)
+    LogNotContainsMatch(t, r, m, settings...)
+}
+
+// LogNotContainsMatch asserts r recorded no entry satisfying m.
+func LogNotContainsMatch(t T, r *LogRecorder, m LogMatch, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.LogNotContains(r.Entries(), m, options(settings...)), settings...)
+}
+
+// LogCount asserts r recorded exactly n entries of level, with a message
+// matching msg, and with the attributes of args. Arguments are as for
+// LogContains.
+func LogCount(t T, r *LogRecorder, n int, level slog.Level, msg string, args ...any) {
+    t.Helper()
+    m, settings := logMatch(level, msg, args)
+    LogCountMatch(t, r, n, m, settings...)
+}
+
+// LogCountMatch asserts r recorded exactly n entries satisfying m.
+func LogCountMatch(t T, r *LogRecorder, n int, m LogMatch, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.LogCount(n, r.Entries(), m, options(settings...)), settings...)
+}
+
+// LogOrdered asserts r recorded entries satisfying each of matches, in order.
+// Other entries may be recorded in between.
+func LogOrdered(t T, r *LogRecorder, matches []LogMatch, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.LogOrdered(r.Entries(), matches, options(settings...)), settings...)
+}
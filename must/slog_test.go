@@ -0,0 +1,95 @@
+package must
+
+import (
+    "log/slog"
+    "strings"
+    "testing"
+
+    "github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestCaptureSlog(t *testing.T) {
+    logger, rec := CaptureSlog(t)
+    logger.Debug("starting", "attempt", 1)
+    logger.With("service", "api").WithGroup("req").Info("handled request", "path", "/users", "status", 200)
+    logger.Warn("slow request", slog.Group("timing", slog.Int("ms", 1500)))
+
+    LogContains(t, rec, slog.LevelInfo, `^handled`, "service", "api", slog.String("req.path", "/users"))
+    LogContainsMatch(t, rec, LogMatch{
+        Level:   slog.LevelWarn,
+        Message: `slow`,
+        Attrs:   []slog.Attr{slog.Group("timing", slog.Int("ms", 1500))},
+    })
+    LogNotContains(t, rec, slog.LevelError, `.*`)
+    LogCount(t, rec, 1, slog.LevelDebug, `starting`, "attempt", 1)
+    LogOrdered(t, rec, []LogMatch{
+        {Level: slog.LevelDebug, Message: `starting`},
+        {Level: slog.LevelWarn, Message: `slow`},
+    })
+}
+
+func TestLogContains(t *testing.T) {
+    tc := newCase(t, `expected log entry matching`)
+    t.Cleanup(tc.assert)
+
+    logger, rec := CaptureSlog(tc)
+    logger.Info("hello", "user", "alice")
+    LogContains(tc, rec, slog.LevelInfo, `hello`, "user", "bob")
+
+    if !strings.Contains(tc.capture, `INFO "hello" user="alice"`) {
+        t.Fatalf("expected captured entries in output, got %q", tc.capture)
+    }
+}
+
+func TestLogContains_cmp(t *testing.T) {
+    logger, rec := CaptureSlog(t)
+    logger.Info("measured", "value", 1.05)
+    LogContains(t, rec, slog.LevelInfo, `measured`, "value", 1.0, Cmp(cmpopts.EquateApprox(0, 0.1)))
+    m := LogMatch{Level: slog.LevelInfo, Message: `measured`, Attrs: []slog.Attr{slog.Float64("value", 1.0)}}
+    LogContainsMatch(t, rec, m, Cmp(cmpopts.EquateApprox(0, 0.1)))
+
+    tc := newCase(t, `expected log entry matching`)
+    t.Cleanup(tc.assert)
+    LogContainsMatch(tc, rec, m)
+}
+
+func TestLogNotContains(t *testing.T) {
+    tc := newCase(t, `expected no log entry matching`)
+    t.Cleanup(tc.assert)
+
+    logger, rec := CaptureSlog(tc)
+    logger.Error("boom")
+    LogNotContains(tc, rec, slog.LevelError, `boom`)
+}
+
+func TestLogCount(t *testing.T) {
+    tc := newCase(t, `count: 2, expected: 1`)
+    t.Cleanup(tc.assert)
+
+    logger, rec := CaptureSlog(tc)
+    logger.Info("tick")
+    logger.Info("tick")
+    LogCount(tc, rec, 1, slog.LevelInfo, `tick`)
+}
+
+func TestLogOrdered(t *testing.T) {
+    tc := newCase(t, `matched 2 of 3; missing: INFO /first/`)
+    t.Cleanup(tc.assert)
+
+    logger, rec := CaptureSlog(tc)
+    logger.Info("second")
+    logger.Info("first")
+    LogOrdered(tc, rec, []LogMatch{
+        {Level: slog.LevelInfo, Message: `second`},
+        {Level: slog.LevelInfo, Message: `first`},
+        {Level: slog.LevelInfo, Message: `first`},
+    })
+}
+
+func TestLogContains_settings(t *testing.T) {
+    tc := newCase(t, `↪ Stack trace ↷`)
+    t.Cleanup(tc.assert)
+
+    _, rec := CaptureSlog(tc)
+    LogContains(tc, rec, slog.LevelInfo, `missing`, StackTrace())
+}
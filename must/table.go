@@ -0,0 +1,92 @@
+package must
+
+import (
+    "github.com/ninepeach/go-test/assertions"
+)
+
+// Case is a named case of a table driven test run by Table.
+type Case[In, Out any] struct {
+    // Name is the name of the subtest running the case.
+    Name string
+
+    // In is the input passed to the function under test.
+    In In
+
+    // Out is the expected output of the function under test, compared using
+    // Compare when the function does not return an error.
+    Out Out
+
+    // Compare compares Out with the actual output. Defaults to Eq.
+    Compare ComparisonAssertionFunc[Out]
+
+    // Check asserts on the actual output instead of comparing it with Out.
+    Check ValueAssertionFunc[Out]
+
+    // Err asserts on the error returned by the function under test. Defaults
+    // to NoError.
+    Err ErrorAssertionFunc
+
+    // Panics expects the function under test to panic.
+    Panics bool
+
+    // PanicValue, if not nil, is the value the function under test is
+    // expected to panic with, compared using cmp.Equal. It implies Panics.
+    PanicValue any
+
+    // Parallel runs the case in parallel with other parallel cases.
+    Parallel bool
+
+    // Settings are applied to each assertion made for the case.
+    Settings []Setting
+}
+
+// Runner is a T able to run subtests of its own type, such as *testing.T.
+type Runner[R any] interface {
+    T
+    Run(name string, f func(R)) bool
+}
+
+// Table runs each of cases as a subtest of t, passing the input of the case to
+// fn and asserting on its output, error or panic. Cases marked Parallel are
+// run in parallel if t supports it.
+func Table[In, Out any, R Runner[R]](t R, cases []Case[In, Out], fn func(In) (Out, error)) {
+    t.Helper()
+    for _, c := range cases {
+        t.Run(c.Name, func(t R) {
+            t.Helper()
+            if p, ok := any(t).(interface{ Parallel() }); ok && c.Parallel {
+                p.Parallel()
+            }
+
+            call := func() { _, _ = fn(c.In) }
+            switch {
+            case c.PanicValue != nil:
+                invoke(t, assertions.PanicsWith(c.PanicValue, call, options(c.Settings...)...), c.Settings...)
+                return
+            case c.Panics:
+                invoke(t, assertions.Panics(call), c.Settings...)
+                return
+            }
+
+            out, err := fn(c.In)
+
+            errCheck := c.Err
+            if errCheck == nil {
+                errCheck = NoError
+            }
+            errCheck(t, err, c.Settings...)
+            if err != nil {
+                return
+            }
+
+            switch {
+            case c.Check != nil:
+                c.Check(t, out, c.Settings...)
+            case c.Compare != nil:
+                c.Compare(t, c.Out, out, c.Settings...)
+            default:
+                Eq(t, c.Out, out, c.Settings...)
+            }
+        })
+    }
+}
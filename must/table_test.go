@@ -0,0 +1,99 @@
+package must
+
+import (
+    "errors"
+    "strconv"
+    "testing"
+
+    "github.com/google/go-cmp/cmp/cmpopts"
+    "github.com/ninepeach/go-test/musttest"
+)
+
+func TestNoError(t *testing.T) {
+    tc := newCase(t, `expected nil error`)
+    t.Cleanup(tc.assert)
+
+    NoError(tc, errors.New("oops"))
+}
+
+func TestTable(t *testing.T) {
+    parse := func(s string) (int, error) {
+        if s == "boom" {
+            panic("boom")
+        }
+        return strconv.Atoi(s)
+    }
+
+    Table(t, []Case[string, int]{
+        {Name: "number", In: "42", Out: 42},
+        {Name: "negative", In: "-7", Out: -7, Parallel: true},
+        {Name: "error", In: "foo", Err: Error},
+        {Name: "panic", In: "boom", Panics: true},
+        {Name: "compare", In: "3", Out: 4, Compare: NotEq[int]},
+        {Name: "check", In: "9", Check: NonZero[int]},
+    }, parse)
+}
+
+func TestTable_settings(t *testing.T) {
+    type pair struct {
+        Key   string
+        value int
+    }
+
+    Table(t, []Case[string, pair]{
+        {
+            Name:     "ignore unexported",
+            In:       "a",
+            Out:      pair{Key: "a"},
+            Settings: []Setting{Cmp(cmpopts.IgnoreUnexported(pair{}))},
+        },
+    }, func(s string) (pair, error) {
+        return pair{Key: s, value: 1}, nil
+    })
+}
+
+func TestTable_failures(t *testing.T) {
+    parse := func(s string) (int, error) {
+        if s == "boom" {
+            panic("boom")
+        }
+        return strconv.Atoi(s)
+    }
+
+    t.Run("wrong output", func(t *testing.T) {
+        rec := musttest.New(t)
+        Table(rec, []Case[string, int]{{Name: "number", In: "42", Out: 43}}, parse)
+        rec.ExpectFailure("number: ")
+        rec.ExpectFailure("expected equality via cmp.Equal function")
+    })
+
+    t.Run("unexpected error", func(t *testing.T) {
+        rec := musttest.New(t)
+        Table(rec, []Case[string, int]{{Name: "word", In: "foo", Out: 1}}, parse)
+        rec.ExpectFailure("word: ")
+        rec.ExpectFailure("expected nil error")
+    })
+
+    t.Run("missing error", func(t *testing.T) {
+        rec := musttest.New(t)
+        Table(rec, []Case[string, int]{{Name: "number", In: "1", Err: Error}}, parse)
+        rec.ExpectFailure("expected non-nil error")
+    })
+
+    t.Run("missing panic", func(t *testing.T) {
+        rec := musttest.New(t)
+        Table(rec, []Case[string, int]{{Name: "number", In: "1", Panics: true}}, parse)
+        rec.ExpectFailure("expected function to panic; it did not")
+    })
+
+    t.Run("panic value", func(t *testing.T) {
+        rec := musttest.New(t)
+        Table(rec, []Case[string, int]{
+            {Name: "match", In: "boom", PanicValue: "boom"},
+            {Name: "mismatch", In: "boom", PanicValue: "bang"},
+        }, parse)
+        SliceLen(t, 1, rec.Failures())
+        rec.ExpectFailure("mismatch: ")
+        rec.ExpectFailure("expected function to panic with different value")
+    })
+}
@@ -0,0 +1,17 @@
+package must
+
+import (
+    "time"
+
+    "github.com/ninepeach/go-test/assertions"
+)
+
+// CompletesWithin asserts fn returns within d. Otherwise the test fails with
+// the stacks of all goroutines, leaving out frames of the runtime and testing
+// packages, to help locate a deadlock. fn is left running in the background
+// after a failure. If fn panics, the test fails with the panic value and the
+// stack of fn.
+func CompletesWithin(t T, d time.Duration, fn func(), settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.CompletesWithin(d, fn), settings...)
+}
@@ -0,0 +1,56 @@
+package must
+
+import (
+    "strings"
+    "sync"
+    "testing"
+    "time"
+)
+
+// lockedTwice blocks forever, as a deadlock in the code under test would.
+func lockedTwice(mu *sync.Mutex) {
+    mu.Lock()
+    mu.Lock()
+}
+
+func TestCompletesWithin(t *testing.T) {
+    t.Run("completes", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        CompletesWithin(tc, time.Second, func() {})
+    })
+
+    t.Run("panic", func(t *testing.T) {
+        tc := newCase(t, "expected function to complete; it panicked\n↪ panic: boom\n↪ stack ↷")
+        t.Cleanup(func() {
+            tc.assert()
+            True(t, strings.Contains(tc.capture, "timeout_test.go:"))
+        })
+
+        CompletesWithin(tc, time.Second, func() { panic("boom") })
+    })
+
+    t.Run("deadlock", func(t *testing.T) {
+        tc := newCapture(t)
+
+        var mu sync.Mutex
+        CompletesWithin(tc, 50*time.Millisecond, func() { lockedTwice(&mu) })
+        defer mu.Unlock()
+
+        for _, sub := range []string{
+            "expected function to complete within 50ms",
+            "must.lockedTwice",
+            "timeout_test.go:",
+        } {
+            if !strings.Contains(tc.capture, sub) {
+                t.Fatalf("expected %q in output, got:\n%s", sub, tc.capture)
+            }
+        }
+        for _, sub := range []string{"runtime.gopark", "testing.tRunner", "sync.runtime_"} {
+            if strings.Contains(tc.capture, sub) {
+                t.Fatalf("expected %q to be filtered from output, got:\n%s", sub, tc.capture)
+            }
+        }
+    })
+}
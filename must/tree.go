@@ -0,0 +1,19 @@
+package must
+
+import (
+    "io/fs"
+
+    "github.com/ninepeach/go-test/assertions"
+)
+
+// DirTreeEq asserts the directory trees exp and val contain the same files
+// and directories, with the same permissions and content. Failures list
+// missing and extra paths, file and directory mode differences and a diff of
+// each file whose content differs.
+//
+// Use IgnorePaths, NormalizeLineEndings and IgnoreModes to relax the
+// comparison.
+func DirTreeEq(t T, exp, val fs.FS, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.DirTreeEq(exp, val, apply(settings...).treeOptions), settings...)
+}
@@ -0,0 +1,119 @@
+package must
+
+import (
+    "io/fs"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "testing/fstest"
+)
+
+var expTree = fstest.MapFS{
+    "main.go":          {Data: []byte("package main\n\nfunc main() {}\n"), Mode: 0o644},
+    "gen/types.go":     {Data: []byte("package gen\n\ntype A int\ntype B int\n"), Mode: 0o644},
+    "gen/run.sh":       {Data: []byte("#!/bin/sh\n"), Mode: 0o755},
+    "gen/blob.bin":     {Data: []byte{0x00, 0xff, 0x10}, Mode: 0o644},
+    "docs/README.md":   {Data: []byte("# docs\n"), Mode: 0o644},
+    "docs/.cache/temp": {Data: []byte("x"), Mode: 0o644},
+}
+
+func cloneTree(m fstest.MapFS) fstest.MapFS {
+    clone := make(fstest.MapFS, len(m))
+    for name, file := range m {
+        f := *file
+        f.Data = append([]byte(nil), file.Data...)
+        clone[name] = &f
+    }
+    return clone
+}
+
+func TestDirTreeEq(t *testing.T) {
+    t.Run("equal", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        DirTreeEq(tc, expTree, cloneTree(expTree))
+    })
+
+    t.Run("differences", func(t *testing.T) {
+        tc := newCapture(t)
+
+        val := cloneTree(expTree)
+        delete(val, "docs/README.md")
+        val["extra.txt"] = &fstest.MapFile{Data: []byte("extra")}
+        val["gen/run.sh"].Mode = 0o644
+        val["gen/types.go"].Data = []byte("package gen\n\ntype A int\ntype C int\n")
+        val["gen/blob.bin"].Data = []byte{0x00, 0xfe, 0x10}
+        DirTreeEq(tc, expTree, val)
+
+        for _, sub := range []string{
+            "expected equality of directory trees",
+            "↪ missing: docs/README.md",
+            "↪   extra: extra.txt",
+            `↪ mode of "gen/run.sh": -rw-r--r--, expected: -rwxr-xr-x`,
+            `↪ content of "gen/types.go" differs`,
+            "type B int",
+            "type C int",
+            `↪ content of "gen/blob.bin" differs`,
+        } {
+            if !strings.Contains(tc.capture, sub) {
+                t.Fatalf("expected %q in output, got:\n%s", sub, tc.capture)
+            }
+        }
+    })
+
+    t.Run("ignore", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        val := cloneTree(expTree)
+        delete(val, "docs/.cache/temp")
+        val["docs/.cache/other"] = &fstest.MapFile{Data: []byte("y")}
+        val["gen/types.go.orig"] = &fstest.MapFile{Data: []byte("y")}
+        DirTreeEq(tc, expTree, val, IgnorePaths(".cache", "*.orig"))
+    })
+
+    t.Run("line endings", func(t *testing.T) {
+        val := cloneTree(expTree)
+        val["main.go"].Data = []byte("package main\r\n\r\nfunc main() {}\r\n")
+
+        tc := newCase(t, `content of "main.go" differs`)
+        DirTreeEq(tc, expTree, val)
+        tc.assert()
+
+        tc = newCapture(t)
+        DirTreeEq(tc, expTree, val, NormalizeLineEndings())
+        tc.assertNot()
+    })
+
+    t.Run("directory mode", func(t *testing.T) {
+        val := cloneTree(expTree)
+        val["gen"] = &fstest.MapFile{Mode: fs.ModeDir | 0o700}
+
+        tc := newCase(t, `mode of "gen": drwx------, expected: dr-xr-xr-x`)
+        DirTreeEq(tc, expTree, val)
+        tc.assert()
+
+        tc = newCapture(t)
+        DirTreeEq(tc, expTree, val, IgnoreModes())
+        tc.assertNot()
+    })
+
+    t.Run("os", func(t *testing.T) {
+        dir := t.TempDir()
+        for name, file := range expTree {
+            path := filepath.Join(dir, filepath.FromSlash(name))
+            NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+            NoError(t, os.WriteFile(path, file.Data, 0o600))
+        }
+
+        tc := newCase(t, `mode of "main.go": -rw-------, expected: -rw-r--r--`)
+        DirTreeEq(tc, expTree, os.DirFS(dir))
+        tc.assert()
+
+        tc = newCapture(t)
+        DirTreeEq(tc, expTree, os.DirFS(dir), IgnoreModes())
+        tc.assertNot()
+    })
+}
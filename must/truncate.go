@@ -0,0 +1,121 @@
+package must
+
+import (
+    "fmt"
+    "os"
+    "strings"
+    "unicode/utf8"
+)
+
+// diffContext is the number of unchanged lines kept around each changed line
+// when eliding lines of a failure message.
+const diffContext = 2
+
+// bound applies the message size settings to the failure message msg.
+func bound(msg string, s *Settings) string {
+    if s.diffFile {
+        return writeDiffFile(msg)
+    }
+    if s.maxValueLen > 0 {
+        msg = truncateValues(msg, s.maxValueLen)
+    }
+    if s.maxDiffLines > 0 {
+        msg = truncateLines(msg, s.maxDiffLines)
+    }
+    return msg
+}
+
+// interesting reports whether line carries information which should survive
+// truncation: the headline, annotations and the changed lines of a diff.
+func interesting(i int, line string) bool {
+    return i == 0 ||
+        strings.HasPrefix(line, "↪") ||
+        strings.HasPrefix(line, "-") ||
+        strings.HasPrefix(line, "+")
+}
+
+func elided(n int) string {
+    return fmt.Sprintf("⋮ %d line(s) elided", n)
+}
+
+// truncateLines reduces msg to at most max lines, keeping interesting lines
+// along with a little context and noting how many lines were elided. The
+// headline is always kept, so at least two lines remain.
+func truncateLines(msg string, max int) string {
+    if max < 2 {
+        max = 2
+    }
+    lines := strings.Split(msg, "\n")
+    if len(lines) <= max {
+        return msg
+    }
+
+    keep := make([]bool, len(lines))
+    for i, line := range lines {
+        if !interesting(i, line) {
+            continue
+        }
+        for j := i - diffContext; j <= i+diffContext; j++ {
+            if j >= 0 && j < len(lines) {
+                keep[j] = true
+            }
+        }
+    }
+
+    var result []string
+    skipped := 0
+    for i, line := range lines {
+        if !keep[i] {
+            skipped++
+            continue
+        }
+        if skipped > 0 {
+            result = append(result, elided(skipped))
+            skipped = 0
+        }
+        result = append(result, line)
+    }
+    if skipped > 0 {
+        result = append(result, elided(skipped))
+    }
+
+    if len(result) > max {
+        shown := 0
+        for _, line := range result[:max-1] {
+            if !strings.HasPrefix(line, "⋮ ") {
+                shown++
+            }
+        }
+        result = append(result[:max-1], fmt.Sprintf("⋮ %d more line(s) elided", len(lines)-shown))
+    }
+    return strings.Join(result, "\n")
+}
+
+// truncateValues shortens each line of msg longer than max characters.
+func truncateValues(msg string, max int) string {
+    lines := strings.Split(msg, "\n")
+    for i, line := range lines {
+        n := utf8.RuneCountInString(line)
+        if n <= max {
+            continue
+        }
+        runes := []rune(line)
+        lines[i] = string(runes[:max]) + fmt.Sprintf("…(%d more characters)", n-max)
+    }
+    return strings.Join(lines, "\n")
+}
+
+// writeDiffFile writes msg to a temporary file, returning the headline of msg
+// along with the path of the file.
+func writeDiffFile(msg string) string {
+    headline, _, _ := strings.Cut(msg, "\n")
+    f, err := os.CreateTemp("", "must-failure-*.txt")
+    if err != nil {
+        return msg + fmt.Sprintf("\n↪ unable to write failure to file: %v", err)
+    }
+    defer f.Close()
+    if _, err := f.WriteString(msg + "\n"); err != nil {
+        return msg + fmt.Sprintf("\n↪ unable to write failure to file: %v", err)
+    }
+    return headline + fmt.Sprintf("\n↪ full failure written to %s", f.Name())
+}
@@ -0,0 +1,104 @@
+package must
+
+import (
+    "fmt"
+    "os"
+    "strings"
+    "testing"
+)
+
+func TestTruncateLines(t *testing.T) {
+    lines := []string{"expected equality"}
+    for i := 0; i < 20; i++ {
+        lines = append(lines, "  same")
+    }
+    lines = append(lines, "- old", "+ new")
+    for i := 0; i < 20; i++ {
+        lines = append(lines, "  same")
+    }
+
+    result := truncateLines(strings.Join(lines, "\n"), 20)
+    exp := strings.Join([]string{
+        "expected equality",
+        "  same",
+        "  same",
+        "⋮ 16 line(s) elided",
+        "  same",
+        "  same",
+        "- old",
+        "+ new",
+        "  same",
+        "  same",
+        "⋮ 18 line(s) elided",
+    }, "\n")
+    if result != exp {
+        t.Fatalf("expected:\n%s\ngot:\n%s", exp, result)
+    }
+
+    result = truncateLines(strings.Join(lines, "\n"), 4)
+    exp = strings.Join([]string{
+        "expected equality",
+        "  same",
+        "  same",
+        "⋮ 40 more line(s) elided",
+    }, "\n")
+    if result != exp {
+        t.Fatalf("expected:\n%s\ngot:\n%s", exp, result)
+    }
+}
+
+func TestTruncateValues(t *testing.T) {
+    result := truncateValues("short\n"+strings.Repeat("x", 30), 10)
+    if exp := "short\nxxxxxxxxxx…(20 more characters)"; result != exp {
+        t.Fatalf("expected %q, got %q", exp, result)
+    }
+}
+
+func TestMaxDiffLines(t *testing.T) {
+    tc := newCase(t, `line(s) elided`)
+    t.Cleanup(tc.assert)
+
+    a := make([]int, 5000)
+    b := make([]int, 5000)
+    for i := range b {
+        b[i] = i
+    }
+    Eq(tc, a, b, MaxDiffLines(15))
+
+    if n := strings.Count(tc.capture, "\n") + 1; n > 15 {
+        t.Fatalf("expected at most 15 lines, got %d", n)
+    }
+}
+
+func TestMaxDiffLines_small(t *testing.T) {
+    for _, max := range []int{1, 2} {
+        t.Run(fmt.Sprint(max), func(t *testing.T) {
+            tc := newCase(t, `expected equality via cmp.Equal function`)
+            t.Cleanup(func() {
+                tc.assert()
+                lines := strings.Split(strings.TrimSpace(tc.capture), "\n")
+                SliceLen(t, 2, lines)
+                True(t, strings.HasPrefix(lines[1], "⋮ ") && strings.HasSuffix(lines[1], "more line(s) elided"))
+            })
+
+            Eq(tc, []int{1, 2, 3}, []int{1, 2, 4}, MaxDiffLines(max))
+        })
+    }
+}
+
+func TestDiffFile(t *testing.T) {
+    tc := newCase(t, `full failure written to`)
+    t.Cleanup(tc.assert)
+
+    Eq(tc, []int{1, 2, 3}, []int{1, 2, 4}, DiffFile())
+
+    _, path, _ := strings.Cut(tc.capture, "full failure written to ")
+    b, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatal(err)
+    }
+    t.Cleanup(func() { _ = os.Remove(path) })
+    if !strings.Contains(string(b), "Assertion | differential") {
+        t.Fatalf("expected diff in file, got %q", b)
+    }
+}
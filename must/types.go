@@ -0,0 +1,48 @@
+package must
+
+import (
+    "reflect"
+
+    "github.com/ninepeach/go-test/assertions"
+)
+
+// IsType asserts the dynamic type of v is A, or implements A if A is an
+// interface, returning v as an A. Failures give the full package path of the
+// types involved.
+func IsType[A any](t T, v any, settings ...Setting) A {
+    t.Helper()
+    invoke(t, assertions.IsType[A](v), settings...)
+    a, _ := v.(A)
+    return a
+}
+
+// Implements asserts the dynamic type of v implements the interface I, listing
+// the missing methods on failure.
+func Implements[I any](t T, v any, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.Implements[I](v), settings...)
+}
+
+// AssignableTo asserts the dynamic type of v is assignable to type A.
+func AssignableTo[A any](t T, v any, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.AssignableTo[A](v), settings...)
+}
+
+// ConvertibleTo asserts the dynamic type of v is convertible to type A.
+func ConvertibleTo[A any](t T, v any, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.ConvertibleTo[A](v), settings...)
+}
+
+// SameType asserts the dynamic types of a and b are identical.
+func SameType(t T, a, b any, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.SameType(a, b), settings...)
+}
+
+// Kind asserts the dynamic type of v is of kind.
+func Kind(t T, v any, kind reflect.Kind, settings ...Setting) {
+    t.Helper()
+    invoke(t, assertions.Kind(v, kind), settings...)
+}
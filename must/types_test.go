@@ -0,0 +1,179 @@
+package must
+
+import (
+    "fmt"
+    "io"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+type plugin struct {
+    name string
+}
+
+func (p *plugin) String() string {
+    return p.name
+}
+
+type celsius float64
+
+type badReader struct{}
+
+func (badReader) Read(s string) int {
+    return len(s)
+}
+
+func TestIsType(t *testing.T) {
+    t.Run("match", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        var v any = &plugin{name: "a"}
+        p := IsType[*plugin](tc, v)
+        Eq(t, "a", p.name)
+    })
+
+    t.Run("mismatch", func(t *testing.T) {
+        tc := newCase(t, "↪     type: map[string]*github.com/ninepeach/go-test/must.plugin\n↪ expected: *github.com/ninepeach/go-test/must.plugin")
+        t.Cleanup(tc.assert)
+
+        p := IsType[*plugin](tc, map[string]*plugin{})
+        Nil(t, p)
+    })
+
+    t.Run("func", func(t *testing.T) {
+        tc := newCase(t, "↪     type: func(*github.com/ninepeach/go-test/must.plugin, ...github.com/ninepeach/go-test/must.celsius) (int, error)")
+        t.Cleanup(tc.assert)
+
+        IsType[*plugin](tc, func(*plugin, ...celsius) (int, error) { return 0, nil })
+    })
+
+    t.Run("struct", func(t *testing.T) {
+        tc := newCase(t, "↪     type: struct { P *github.com/ninepeach/go-test/must.plugin \"json:\\\"p\\\"\"; github.com/ninepeach/go-test/must.celsius }")
+        t.Cleanup(tc.assert)
+
+        IsType[*plugin](tc, struct {
+            P *plugin `json:"p"`
+            celsius
+        }{})
+    })
+
+    t.Run("interface", func(t *testing.T) {
+        tc := newCase(t, "↪     type: *interface { Plugin() github.com/ninepeach/go-test/must.plugin }")
+        t.Cleanup(tc.assert)
+
+        IsType[*plugin](tc, new(interface{ Plugin() plugin }))
+    })
+}
+
+func TestImplements(t *testing.T) {
+    t.Run("implements", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        Implements[fmt.Stringer](tc, &plugin{})
+    })
+
+    t.Run("missing", func(t *testing.T) {
+        tc := newCase(t, "↪   missing: Read([]uint8) (int, error)")
+        t.Cleanup(tc.assert)
+
+        Implements[io.Reader](tc, &plugin{})
+    })
+
+    t.Run("wrong signature", func(t *testing.T) {
+        tc := newCase(t, "↪     wrong: Read(string) int, expected: Read([]uint8) (int, error)")
+        t.Cleanup(tc.assert)
+
+        Implements[io.Reader](tc, badReader{})
+    })
+
+    t.Run("pointer receiver", func(t *testing.T) {
+        tc := newCase(t, "↪   missing: String() string (method has pointer receiver)")
+        t.Cleanup(tc.assert)
+
+        Implements[fmt.Stringer](tc, plugin{})
+    })
+
+    t.Run("not interface", func(t *testing.T) {
+        tc := newCase(t, "expected an interface type to check against")
+        t.Cleanup(tc.assert)
+
+        Implements[plugin](tc, &plugin{})
+    })
+
+    t.Run("nil", func(t *testing.T) {
+        tc := newCase(t, "↪      value: nil")
+        t.Cleanup(tc.assert)
+
+        Implements[fmt.Stringer](tc, nil)
+    })
+}
+
+func TestAssignableTo(t *testing.T) {
+    t.Run("assignable", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        AssignableTo[fmt.Stringer](tc, &plugin{})
+    })
+
+    t.Run("not assignable", func(t *testing.T) {
+        tc := newCase(t, "↪   to: github.com/ninepeach/go-test/must.celsius")
+        t.Cleanup(tc.assert)
+
+        AssignableTo[celsius](tc, 1.5)
+    })
+}
+
+func TestConvertibleTo(t *testing.T) {
+    t.Run("convertible", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        ConvertibleTo[celsius](tc, 1.5)
+    })
+
+    t.Run("not convertible", func(t *testing.T) {
+        tc := newCase(t, "expected value to be convertible to type")
+        t.Cleanup(tc.assert)
+
+        ConvertibleTo[celsius](tc, "1.5")
+    })
+}
+
+func TestSameType(t *testing.T) {
+    t.Run("same", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        SameType(tc, celsius(1), celsius(2))
+    })
+
+    t.Run("different", func(t *testing.T) {
+        tc := newCase(t, "↪ a: github.com/ninepeach/go-test/must.celsius\n↪ b: float64")
+        t.Cleanup(tc.assert)
+
+        SameType(tc, celsius(1), 2.0)
+    })
+}
+
+func TestKind(t *testing.T) {
+    t.Run("match", func(t *testing.T) {
+        tc := newCapture(t)
+        t.Cleanup(tc.assertNot)
+
+        Kind(tc, plugin{}, reflect.Struct)
+    })
+
+    t.Run("mismatch", func(t *testing.T) {
+        tc := newCase(t, "kind: ptr, expected: struct")
+        t.Cleanup(func() {
+            tc.assert()
+            True(t, strings.Contains(tc.capture, "type: *github.com/ninepeach/go-test/must.plugin"))
+        })
+
+        Kind(tc, &plugin{}, reflect.Struct)
+    })
+}
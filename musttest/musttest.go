@@ -0,0 +1,250 @@
+package musttest
+
+import (
+    "fmt"
+    "os"
+    "runtime"
+    "strings"
+    "sync"
+)
+
+// T is a fake test recording failures, helper marking, logs and cleanups. It
+// satisfies must.T, so it can be passed to custom assertion helpers built on
+// the must package in order to unit test them.
+//
+// Unlike testing.T, Fatalf does not stop the calling goroutine; execution of
+// the helper continues after a failure is recorded.
+type T struct {
+    tb Reporter
+
+    lock     sync.Mutex
+    helpers  []helperCall
+    failures []string
+    logs     []string
+    cleanups []func()
+}
+
+// helperCall records the function which called Helper, and its caller.
+type helperCall struct {
+    helper, caller string
+}
+
+// maxDepth bounds the frames inspected when matching calls to Helper against
+// the caller of an expectation.
+const maxDepth = 64
+
+// Reporter is the subset of testing.TB used to report unmet expectations. It
+// is satisfied by *testing.T and by *T itself.
+type Reporter interface {
+    Helper()
+    Fatalf(string, ...any)
+    Cleanup(func())
+}
+
+// New creates a T reporting unmet expectations to tb. Cleanup functions
+// registered with T which were not run explicitly are run when tb completes.
+func New(tb Reporter) *T {
+    t := &T{tb: tb}
+    tb.Cleanup(t.RunCleanups)
+    return t
+}
+
+// Helper records that the function calling it marked itself as a helper,
+// along with the function it was called from.
+func (t *T) Helper() {
+    pcs := make([]uintptr, 4)
+    frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+    var call helperCall
+    if frame, more := frames.Next(); frame.Function != "" {
+        call.helper = frame.Function
+        if more {
+            frame, _ = frames.Next()
+            call.caller = frame.Function
+        }
+    }
+    t.lock.Lock()
+    defer t.lock.Unlock()
+    t.helpers = append(t.helpers, call)
+}
+
+// Fatalf records a failure.
+func (t *T) Fatalf(msg string, args ...any) {
+    t.lock.Lock()
+    defer t.lock.Unlock()
+    t.failures = append(t.failures, strings.TrimSpace(fmt.Sprintf(msg, args...)))
+}
+
+// Errorf records a failure.
+func (t *T) Errorf(msg string, args ...any) {
+    t.Fatalf(msg, args...)
+}
+
+// Logf records a log message.
+func (t *T) Logf(msg string, args ...any) {
+    t.lock.Lock()
+    defer t.lock.Unlock()
+    t.logs = append(t.logs, strings.TrimSpace(fmt.Sprintf(msg, args...)))
+}
+
+// Log records a log message.
+func (t *T) Log(args ...any) {
+    t.Logf("%s", fmt.Sprintln(args...))
+}
+
+// Cleanup records f to be run by RunCleanups.
+func (t *T) Cleanup(f func()) {
+    t.lock.Lock()
+    defer t.lock.Unlock()
+    t.cleanups = append(t.cleanups, f)
+}
+
+// Run runs f as a named subtest with a T of its own, and reports whether it
+// passed. Failures recorded by the subtest are recorded by t too, prefixed
+// with name, and its cleanups are run once f returns.
+func (t *T) Run(name string, f func(t *T)) bool {
+    sub := &T{tb: t.tb}
+    defer sub.RunCleanups()
+    f(sub)
+    for _, failure := range sub.Failures() {
+        t.Fatalf("%s: %s", name, failure)
+    }
+    for _, log := range sub.Logs() {
+        t.Logf("%s: %s", name, log)
+    }
+    return !sub.Failed()
+}
+
+// TempDir creates a new temporary directory, which is removed by RunCleanups.
+// A failure to create it is reported to the underlying test.
+func (t *T) TempDir() string {
+    t.tb.Helper()
+    dir, err := os.MkdirTemp("", "musttest")
+    if err != nil {
+        t.tb.Fatalf("failed to create temp dir: %v", err)
+        return ""
+    }
+    t.Cleanup(func() {
+        _ = os.RemoveAll(dir)
+    })
+    return dir
+}
+
+// RunCleanups runs the recorded cleanup functions in last added, first called
+// order. Each function is run at most once.
+func (t *T) RunCleanups() {
+    for {
+        t.lock.Lock()
+        n := len(t.cleanups)
+        if n == 0 {
+            t.lock.Unlock()
+            return
+        }
+        f := t.cleanups[n-1]
+        t.cleanups = t.cleanups[:n-1]
+        t.lock.Unlock()
+        f()
+    }
+}
+
+// Helped reports whether the code under test marked itself as a helper. The
+// code under test is a function called directly from the test, or from any
+// function on the stack of the call to Helped. Calls to Helper made only by
+// functions the code under test calls into, such as the must assertions it
+// uses, do not count.
+func (t *T) Helped() bool {
+    return t.helped(callers())
+}
+
+// callers returns the names of the functions on the stack of the caller of
+// the exported method calling callers.
+func callers() map[string]bool {
+    pcs := make([]uintptr, maxDepth)
+    frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+    names := make(map[string]bool)
+    for {
+        frame, more := frames.Next()
+        names[frame.Function] = true
+        if !more {
+            return names
+        }
+    }
+}
+
+func (t *T) helped(stack map[string]bool) bool {
+    t.lock.Lock()
+    defer t.lock.Unlock()
+    for _, call := range t.helpers {
+        if stack[call.caller] {
+            return true
+        }
+    }
+    return false
+}
+
+// Failed reports whether any failure was recorded.
+func (t *T) Failed() bool {
+    t.lock.Lock()
+    defer t.lock.Unlock()
+    return len(t.failures) > 0
+}
+
+// Failures returns the recorded failure messages.
+func (t *T) Failures() []string {
+    t.lock.Lock()
+    defer t.lock.Unlock()
+    return append([]string(nil), t.failures...)
+}
+
+// Logs returns the recorded log messages.
+func (t *T) Logs() []string {
+    t.lock.Lock()
+    defer t.lock.Unlock()
+    return append([]string(nil), t.logs...)
+}
+
+// ExpectFailure asserts the code under test marked itself as a helper and
+// recorded a failure containing sub. See Helped for what counts as marking.
+func (t *T) ExpectFailure(sub string) {
+    t.tb.Helper()
+    t.expectHelper(callers())
+    failures := t.Failures()
+    if len(failures) == 0 {
+        t.tb.Fatalf("expected failure containing %q; got none", sub)
+        return
+    }
+    for _, failure := range failures {
+        if strings.Contains(failure, sub) {
+            return
+        }
+    }
+    t.tb.Fatalf("expected failure containing %q; got:\n%s", sub, strings.Join(failures, "\n"))
+}
+
+// ExpectPass asserts the code under test marked itself as a helper and did not
+// record any failure. See Helped for what counts as marking.
+func (t *T) ExpectPass() {
+    t.tb.Helper()
+    t.expectHelper(callers())
+    if failures := t.Failures(); len(failures) > 0 {
+        t.tb.Fatalf("expected no failure; got:\n%s", strings.Join(failures, "\n"))
+    }
+}
+
+// ExpectLog asserts the code under test recorded a log message containing sub.
+func (t *T) ExpectLog(sub string) {
+    t.tb.Helper()
+    logs := t.Logs()
+    for _, log := range logs {
+        if strings.Contains(log, sub) {
+            return
+        }
+    }
+    t.tb.Fatalf("expected log containing %q; got:\n%s", sub, strings.Join(logs, "\n"))
+}
+
+func (t *T) expectHelper(stack map[string]bool) {
+    t.tb.Helper()
+    if !t.helped(stack) {
+        t.tb.Fatalf("expected code under test to be marked as helper")
+    }
+}
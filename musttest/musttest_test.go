@@ -0,0 +1,110 @@
+package musttest
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/ninepeach/go-test/must"
+)
+
+// positive is an example of a custom assertion helper built on must.
+func positive(t must.T, n int) {
+    t.Helper()
+    must.True(t, n > 0)
+}
+
+// unmarked is a custom assertion helper which forgets to call t.Helper.
+func unmarked(t must.T, n int) {
+    must.True(t, n > 0)
+}
+
+func TestT_ExpectFailure(t *testing.T) {
+    rec := New(t)
+    positive(rec, -1)
+    rec.ExpectFailure("expected condition to be true")
+    must.True(t, rec.Failed())
+    must.SliceLen(t, 1, rec.Failures())
+}
+
+func TestT_ExpectPass(t *testing.T) {
+    rec := New(t)
+    positive(rec, 1)
+    rec.ExpectPass()
+    must.True(t, rec.Helped())
+}
+
+func TestT_logs(t *testing.T) {
+    rec := New(t)
+    rec.Log("hello", "world")
+    rec.Logf("n=%d", 3)
+    rec.ExpectLog("hello world")
+    must.Eq(t, []string{"hello world", "n=3"}, rec.Logs())
+}
+
+func TestT_cleanups(t *testing.T) {
+    rec := New(t)
+    var order []int
+    rec.Cleanup(func() { order = append(order, 1) })
+    rec.Cleanup(func() { order = append(order, 2) })
+    rec.RunCleanups()
+    rec.RunCleanups()
+    must.Eq(t, []int{2, 1}, order)
+}
+
+func TestT_expectations(t *testing.T) {
+    t.Run("failure not recorded", func(t *testing.T) {
+        outer := New(t)
+        rec := New(outer)
+        positive(rec, 1)
+        rec.ExpectFailure("expected condition")
+        outer.ExpectFailure(`expected failure containing "expected condition"; got none`)
+    })
+
+    t.Run("unexpected failure", func(t *testing.T) {
+        outer := New(t)
+        rec := New(outer)
+        positive(rec, -1)
+        rec.ExpectPass()
+        outer.ExpectFailure("expected no failure")
+    })
+
+    t.Run("not helper", func(t *testing.T) {
+        outer := New(t)
+        rec := New(outer)
+        rec.ExpectPass()
+        outer.ExpectFailure("expected code under test to be marked as helper")
+    })
+
+    t.Run("only must marked helper", func(t *testing.T) {
+        outer := New(t)
+        rec := New(outer)
+        unmarked(rec, -1)
+        rec.ExpectFailure("expected condition to be true")
+        outer.ExpectFailure("expected code under test to be marked as helper")
+        must.False(t, rec.Helped())
+    })
+}
+
+func TestT_TempDir(t *testing.T) {
+    rec := New(t)
+    dir := rec.TempDir()
+    must.DirExists(t, dir)
+    rec.RunCleanups()
+    must.FileNotExists(t, dir)
+}
+
+func TestT_Run(t *testing.T) {
+    rec := New(t)
+    cleaned := false
+    passed := rec.Run("sub", func(t *T) {
+        t.Cleanup(func() { cleaned = true })
+        t.Log("hello")
+        positive(t, -1)
+    })
+    must.False(t, passed)
+    must.True(t, cleaned)
+    must.True(t, rec.Run("ok", func(t *T) { positive(t, 1) }))
+    rec.ExpectLog("sub: hello")
+    must.SliceLen(t, 1, rec.Failures())
+    must.True(t, strings.HasPrefix(rec.Failures()[0], "sub: "))
+}
@@ -0,0 +1,12 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package portal
+
+import "os"
+
+// lockPort reserves nothing across processes where file locks are unsupported.
+func lockPort(string, key) (*os.File, bool, error) {
+    return nil, true, nil
+}
+
+func unlockPort(*os.File) {}
@@ -0,0 +1,50 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package portal
+
+import (
+    "fmt"
+    "os"
+    "syscall"
+)
+
+// lockPort takes an exclusive lock on the lock file of k, which is held until
+// the file is closed by unlockPort or the process exits. A lock file left
+// behind by a process which exited is simply locked again. It reports false if
+// another process holds the lock, and an error if the lock directory is
+// unusable.
+func lockPort(dir string, k key) (*os.File, bool, error) {
+    if err := os.MkdirAll(dir, 0o700); err != nil {
+        return nil, false, fmt.Errorf("portal: lock directory: %w", err)
+    }
+    f, err := os.OpenFile(lockName(dir, k), os.O_CREATE|os.O_RDWR, 0o600)
+    if err != nil {
+        return nil, false, fmt.Errorf("portal: lock file: %w", err)
+    }
+    if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+        _ = f.Close()
+        return nil, false, nil
+    }
+
+    // the holder of the lock may have removed the file between it being opened
+    // and locked here, in which case the lock protects nothing
+    locked, err := f.Stat()
+    if err != nil {
+        _ = f.Close()
+        return nil, false, nil
+    }
+    if current, err := os.Stat(f.Name()); err != nil || !os.SameFile(locked, current) {
+        _ = f.Close()
+        return nil, false, nil
+    }
+    return f, true, nil
+}
+
+// unlockPort removes the lock file while still holding its lock, then releases
+// the lock.
+func unlockPort(f *os.File) {
+    if f != nil {
+        _ = os.Remove(f.Name())
+        _ = f.Close()
+    }
+}
@@ -0,0 +1,21 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package portal
+
+import (
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func TestTake_lockDir(t *testing.T) {
+    // a file where the lock directory should be makes it unusable
+    dir := filepath.Join(t.TempDir(), "file")
+    if err := os.WriteFile(dir, nil, 0o600); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Take(TCP, 1, CrossProcess(), LockDir(dir)); err == nil || errors.Is(err, ErrNoPorts) {
+        t.Fatalf("expected lock directory error, got %v", err)
+    }
+}
@@ -0,0 +1,194 @@
+package portal
+
+import (
+    "errors"
+    "fmt"
+    "math/rand/v2"
+    "net"
+    "os"
+    "path/filepath"
+    "strconv"
+    "sync"
+)
+
+// Default bounds of the range ports are allocated from, chosen below the
+// ephemeral range most systems use for outgoing connections.
+const (
+    defaultMin = 20000
+    defaultMax = 32000
+)
+
+// ErrNoPorts is returned when every port in the range is in use or reserved.
+var ErrNoPorts = errors.New("portal: no free ports left")
+
+// Network is the network a port is allocated for.
+type Network string
+
+const (
+    TCP Network = "tcp"
+    UDP Network = "udp"
+)
+
+// Settings configures how ports are allocated.
+type Settings struct {
+    min, max     int
+    crossProcess bool
+    lockDir      string
+}
+
+// Setting modifies the Settings configuration.
+type Setting func(*Settings)
+
+// Range sets the inclusive range of ports to allocate from.
+func Range(min, max int) Setting {
+    return func(s *Settings) {
+        s.min, s.max = min, max
+    }
+}
+
+// CrossProcess additionally reserves ports with a lock file in a directory of
+// the current user under the system temporary directory, so that test binaries
+// of different packages running at the same time never hand out the same port.
+// Take fails if the lock directory cannot be used. Where file locks are not
+// supported ports are only reserved within the process.
+func CrossProcess() Setting {
+    return func(s *Settings) {
+        s.crossProcess = true
+    }
+}
+
+// LockDir sets the directory of the lock files used by CrossProcess.
+func LockDir(dir string) Setting {
+    return func(s *Settings) {
+        s.lockDir = dir
+    }
+}
+
+func apply(settings ...Setting) *Settings {
+    s := &Settings{
+        min:     defaultMin,
+        max:     defaultMax,
+        lockDir: filepath.Join(os.TempDir(), "go-test-portal-"+userID()),
+    }
+    for _, setting := range settings {
+        setting(s)
+    }
+    return s
+}
+
+// userID identifies the current user in the name of the default lock
+// directory, so that users do not share it.
+func userID() string {
+    if uid := os.Getuid(); uid >= 0 {
+        return strconv.Itoa(uid)
+    }
+    if u := os.Getenv("USERNAME"); u != "" {
+        return u
+    }
+    return "default"
+}
+
+// key identifies a reserved port.
+type key struct {
+    network Network
+    port    int
+}
+
+var (
+    lock     sync.Mutex
+    reserved = make(map[key]*os.File)
+)
+
+// Take reserves n free ports on 127.0.0.1 for network. Reserved ports are not
+// handed out again by this process until they are released.
+func Take(network Network, n int, settings ...Setting) ([]int, error) {
+    if network != TCP && network != UDP {
+        return nil, fmt.Errorf("portal: unsupported network %q", network)
+    }
+    if n < 0 {
+        return nil, fmt.Errorf("portal: invalid number of ports %d", n)
+    }
+    s := apply(settings...)
+    if s.min < 1 || s.max > 65535 || s.min > s.max {
+        return nil, fmt.Errorf("portal: invalid port range %d-%d", s.min, s.max)
+    }
+
+    lock.Lock()
+    defer lock.Unlock()
+
+    ports := make([]int, 0, n)
+    span := s.max - s.min + 1
+    start := rand.IntN(span)
+    for i := 0; i < span && len(ports) < n; i++ {
+        port := s.min + (start+i)%span
+        k := key{network: network, port: port}
+        if _, exists := reserved[k]; exists {
+            continue
+        }
+        var f *os.File
+        if s.crossProcess {
+            locked, ok, err := lockPort(s.lockDir, k)
+            if err != nil {
+                release(network, ports)
+                return nil, err
+            }
+            if !ok {
+                continue
+            }
+            f = locked
+        }
+        if !free(k) {
+            unlockPort(f)
+            continue
+        }
+        reserved[k] = f
+        ports = append(ports, port)
+    }
+
+    if len(ports) < n {
+        release(network, ports)
+        return nil, fmt.Errorf("%w: found %d of %d in range %d-%d", ErrNoPorts, len(ports), n, s.min, s.max)
+    }
+    return ports, nil
+}
+
+// Release returns ports previously reserved by Take for network.
+func Release(network Network, ports ...int) {
+    lock.Lock()
+    defer lock.Unlock()
+    release(network, ports)
+}
+
+func release(network Network, ports []int) {
+    for _, port := range ports {
+        k := key{network: network, port: port}
+        if f, exists := reserved[k]; exists {
+            unlockPort(f)
+            delete(reserved, k)
+        }
+    }
+}
+
+// free reports whether the port of k can currently be bound on 127.0.0.1.
+func free(k key) bool {
+    addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(k.port))
+    switch k.network {
+    case UDP:
+        c, err := net.ListenPacket("udp", addr)
+        if err != nil {
+            return false
+        }
+        return c.Close() == nil
+    default:
+        l, err := net.Listen("tcp", addr)
+        if err != nil {
+            return false
+        }
+        return l.Close() == nil
+    }
+}
+
+// lockName is the name of the lock file reserving the port of k.
+func lockName(dir string, k key) string {
+    return filepath.Join(dir, fmt.Sprintf("%s-%d.lock", k.network, k.port))
+}
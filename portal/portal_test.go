@@ -0,0 +1,112 @@
+package portal
+
+import (
+    "errors"
+    "net"
+    "os"
+    "strconv"
+    "sync"
+    "testing"
+)
+
+func TestTake_distinct(t *testing.T) {
+    var (
+        wg    sync.WaitGroup
+        mu    sync.Mutex
+        seen  = make(map[int]bool)
+        total int
+    )
+    for i := 0; i < 8; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            ports, err := Take(TCP, 5)
+            if err != nil {
+                t.Error(err)
+                return
+            }
+            mu.Lock()
+            defer mu.Unlock()
+            for _, port := range ports {
+                seen[port] = true
+                total++
+            }
+        }()
+    }
+    wg.Wait()
+    if len(seen) != total {
+        t.Fatalf("expected %d distinct ports, got %d", total, len(seen))
+    }
+}
+
+func TestTake_bindable(t *testing.T) {
+    ports, err := Take(UDP, 1)
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer Release(UDP, ports...)
+    c, err := net.ListenPacket("udp", "127.0.0.1:"+strconv.Itoa(ports[0]))
+    if err != nil {
+        t.Fatal(err)
+    }
+    _ = c.Close()
+}
+
+// reservedPort reserves a free port and returns it, so that tests can build
+// their port ranges from a port known to be free on this host.
+func reservedPort(t *testing.T, network Network) int {
+    t.Helper()
+    ports, err := Take(network, 1)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return ports[0]
+}
+
+func TestTake_exhausted(t *testing.T) {
+    port := reservedPort(t, TCP)
+    if _, err := Take(TCP, 1, Range(port, port)); !errors.Is(err, ErrNoPorts) {
+        t.Fatalf("expected ErrNoPorts, got %v", err)
+    }
+    Release(TCP, port)
+    ports, err := Take(TCP, 1, Range(port, port))
+    if err != nil {
+        t.Fatalf("expected released port to be reusable: %v", err)
+    }
+    Release(TCP, ports...)
+}
+
+func TestTake_crossProcess(t *testing.T) {
+    dir := t.TempDir()
+    port := reservedPort(t, TCP)
+    Release(TCP, port)
+    ports, err := Take(TCP, 1, Range(port, port), CrossProcess(), LockDir(dir))
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer Release(TCP, ports...)
+
+    // a lock held by another process looks the same as one held by an open
+    // file of this process
+    if f, ok, _ := lockPort(dir, key{network: TCP, port: port}); ok && f != nil {
+        unlockPort(f)
+        t.Fatal("expected port to be locked")
+    }
+
+    Release(TCP, ports...)
+    if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+        t.Fatalf("expected lock file to be removed, found %d entries", len(entries))
+    }
+}
+
+func TestTake_invalid(t *testing.T) {
+    if _, err := Take("sctp", 1); err == nil {
+        t.Fatal("expected error for unsupported network")
+    }
+    if _, err := Take(TCP, 1, Range(10, 5)); err == nil {
+        t.Fatal("expected error for invalid range")
+    }
+    if _, err := Take(TCP, -1); err == nil {
+        t.Fatal("expected error for negative number of ports")
+    }
+}
@@ -0,0 +1,159 @@
+package prop
+
+import (
+    "fmt"
+    "math/rand/v2"
+    "runtime"
+    "strings"
+
+    "github.com/ninepeach/go-test/assertions"
+    "github.com/ninepeach/go-test/must"
+)
+
+const (
+    defaultRuns       = 100
+    defaultMaxSize    = 100
+    defaultMaxShrinks = 1000
+)
+
+// Settings configures how a property is checked.
+type Settings struct {
+    seed       uint64
+    seeded     bool
+    runs       int
+    maxSize    int
+    maxShrinks int
+}
+
+// Setting modifies the Settings configuration.
+type Setting func(*Settings)
+
+// Seed sets the seed used to generate values, typically to reproduce a failure
+// reported by Check. By default a random seed is used.
+func Seed(seed uint64) Setting {
+    return func(s *Settings) {
+        s.seed = seed
+        s.seeded = true
+    }
+}
+
+// Runs sets the number of generated values the property is checked against.
+func Runs(n int) Setting {
+    return func(s *Settings) {
+        s.runs = n
+    }
+}
+
+// MaxSize sets the size passed to generators on the final run. Sizes grow
+// linearly from zero up to MaxSize over the course of the runs.
+func MaxSize(n int) Setting {
+    return func(s *Settings) {
+        s.maxSize = n
+    }
+}
+
+// MaxShrinks sets the maximum number of candidate values tried while shrinking
+// a counterexample.
+func MaxShrinks(n int) Setting {
+    return func(s *Settings) {
+        s.maxShrinks = n
+    }
+}
+
+func apply(settings ...Setting) *Settings {
+    s := &Settings{
+        runs:       defaultRuns,
+        maxSize:    defaultMaxSize,
+        maxShrinks: defaultMaxShrinks,
+    }
+    for _, setting := range settings {
+        setting(s)
+    }
+    if !s.seeded {
+        s.seed = rand.Uint64()
+    }
+    return s
+}
+
+// recorder is the must.T given to a property, capturing the first failure and
+// stopping the property like testing.T.Fatalf would.
+type recorder struct {
+    failure string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(msg string, args ...any) {
+    r.failure = strings.TrimSpace(fmt.Sprintf(msg, args...))
+    runtime.Goexit()
+}
+
+// evaluate runs property against value, returning the failure message if any
+// assertion failed or the property panicked.
+func evaluate[A any](property func(must.T, A), value A) string {
+    r := new(recorder)
+    done := make(chan struct{})
+    go func() {
+        defer close(done)
+        defer func() {
+            if p := recover(); p != nil {
+                r.failure = fmt.Sprintf("property panicked: %v", p)
+            }
+        }()
+        property(r, value)
+    }()
+    <-done
+    return r.failure
+}
+
+// Check asserts property holds for values produced by gen. The property makes
+// its assertions with the must package against the T it is given. On failure
+// the value is shrunk to a minimal counterexample, which is reported along
+// with the seed needed to reproduce it.
+func Check[A any](t must.T, gen *Gen[A], property func(must.T, A), settings ...Setting) {
+    t.Helper()
+    s := apply(settings...)
+    r := rand.New(rand.NewPCG(s.seed, s.seed))
+    for run := 0; run < s.runs; run++ {
+        size := run * s.maxSize / max(s.runs-1, 1)
+        sample := gen.sample(r, size)
+        failure := evaluate(property, sample.value)
+        if failure == "" {
+            continue
+        }
+        shrunk, failure, shrinks := shrink(sample, property, failure, s.maxShrinks)
+        msg := fmt.Sprintf("property failed after %d run(s) and %d shrink(s)\n", run+1, shrinks)
+        msg += fmt.Sprintf("↪ counterexample: %s\n", assertions.Pretty(shrunk))
+        msg += fmt.Sprintf("↪       original: %s\n", assertions.Pretty(sample.value))
+        msg += fmt.Sprintf("↪           seed: %d (rerun with prop.Seed(%d))\n", s.seed, s.seed)
+        msg += failure
+        t.Fatalf("%s", "\n"+assertions.Caller()+strings.TrimSpace(msg)+"\n")
+        return
+    }
+}
+
+// shrink repeatedly replaces the failing sample with its first shrink candidate
+// which still fails, until no candidate fails or the budget is exhausted.
+func shrink[A any](sample tree[A], property func(must.T, A), failure string, budget int) (A, string, int) {
+    shrinks := 0
+    tried := 0
+    for tried < budget {
+        improved := false
+        for _, candidate := range sample.shrinks() {
+            tried++
+            if f := evaluate(property, candidate.value); f != "" {
+                sample, failure = candidate, f
+                shrinks++
+                improved = true
+                break
+            }
+            if tried >= budget {
+                break
+            }
+        }
+        if !improved {
+            break
+        }
+    }
+    return sample.value, failure, shrinks
+}
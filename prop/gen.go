@@ -0,0 +1,147 @@
+package prop
+
+import (
+    "math/rand/v2"
+)
+
+// maxDiscards is the number of consecutive values Filter may reject before
+// giving up on a generator.
+const maxDiscards = 100
+
+// tree is a generated value along with the lazily computed values it may be
+// shrunk into, ordered from most to least aggressive.
+type tree[A any] struct {
+    value   A
+    shrinks func() []tree[A]
+}
+
+// leaf creates a tree for a value which cannot be shrunk.
+func leaf[A any](value A) tree[A] {
+    return tree[A]{value: value, shrinks: func() []tree[A] { return nil }}
+}
+
+// unfold creates a tree for value by repeatedly applying shrink.
+func unfold[A any](value A, shrink func(A) []A) tree[A] {
+    return tree[A]{
+        value: value,
+        shrinks: func() []tree[A] {
+            var children []tree[A]
+            for _, candidate := range shrink(value) {
+                children = append(children, unfold(candidate, shrink))
+            }
+            return children
+        },
+    }
+}
+
+func mapTree[A, B any](t tree[A], f func(A) B) tree[B] {
+    return tree[B]{
+        value: f(t.value),
+        shrinks: func() []tree[B] {
+            var children []tree[B]
+            for _, child := range t.shrinks() {
+                children = append(children, mapTree(child, f))
+            }
+            return children
+        },
+    }
+}
+
+func filterTree[A any](t tree[A], keep func(A) bool) tree[A] {
+    return tree[A]{
+        value: t.value,
+        shrinks: func() []tree[A] {
+            var children []tree[A]
+            for _, child := range t.shrinks() {
+                if keep(child.value) {
+                    children = append(children, filterTree(child, keep))
+                }
+            }
+            return children
+        },
+    }
+}
+
+// Gen generates random values of type A, each of which knows how to shrink
+// itself toward a minimal counterexample.
+type Gen[A any] struct {
+    sample func(r *rand.Rand, size int) tree[A]
+}
+
+// New creates a generator from a generate function producing values no larger
+// than size, and a shrink function producing simpler candidates of a value.
+// A nil shrink function means values are not shrunk.
+func New[A any](generate func(r *rand.Rand, size int) A, shrink func(A) []A) *Gen[A] {
+    return &Gen[A]{
+        sample: func(r *rand.Rand, size int) tree[A] {
+            value := generate(r, size)
+            if shrink == nil {
+                return leaf(value)
+            }
+            return unfold(value, shrink)
+        },
+    }
+}
+
+// Generate returns a random value no larger than size.
+func (g *Gen[A]) Generate(r *rand.Rand, size int) A {
+    return g.sample(r, size).value
+}
+
+// Just creates a generator which always produces value.
+func Just[A any](value A) *Gen[A] {
+    return &Gen[A]{
+        sample: func(*rand.Rand, int) tree[A] {
+            return leaf(value)
+        },
+    }
+}
+
+// Map creates a generator applying f to the values produced by g. Values are
+// shrunk by shrinking the underlying value of g.
+func Map[A, B any](g *Gen[A], f func(A) B) *Gen[B] {
+    return &Gen[B]{
+        sample: func(r *rand.Rand, size int) tree[B] {
+            return mapTree(g.sample(r, size), f)
+        },
+    }
+}
+
+// Filter creates a generator producing only the values of g for which keep
+// returns true. It panics if keep rejects too many values in a row.
+func Filter[A any](g *Gen[A], keep func(A) bool) *Gen[A] {
+    return &Gen[A]{
+        sample: func(r *rand.Rand, size int) tree[A] {
+            for i := 0; i < maxDiscards; i++ {
+                if t := g.sample(r, size); keep(t.value) {
+                    return filterTree(t, keep)
+                }
+            }
+            panic("prop: Filter discarded too many values")
+        },
+    }
+}
+
+// OneOf creates a generator producing values from one of gens, chosen at random
+// for each value.
+func OneOf[A any](gens ...*Gen[A]) *Gen[A] {
+    if len(gens) == 0 {
+        panic("prop: OneOf requires at least one generator")
+    }
+    return &Gen[A]{
+        sample: func(r *rand.Rand, size int) tree[A] {
+            return gens[r.IntN(len(gens))].sample(r, size)
+        },
+    }
+}
+
+// Elements creates a generator producing one of values, chosen at random.
+// Values shrink toward those listed first.
+func Elements[A any](values ...A) *Gen[A] {
+    if len(values) == 0 {
+        panic("prop: Elements requires at least one value")
+    }
+    return Map(IntRange(0, len(values)-1), func(i int) A {
+        return values[i]
+    })
+}
@@ -0,0 +1,378 @@
+package prop
+
+import (
+    "fmt"
+    "math"
+    "math/rand/v2"
+    "reflect"
+    "unsafe"
+
+    "github.com/ninepeach/go-test/constraints"
+)
+
+// edgeChance is the 1-in-n chance of generating an edge case value instead of
+// a value scaled by size.
+const edgeChance = 10
+
+func intTree[N constraints.Integer](dest, x N) tree[N] {
+    return tree[N]{
+        value: x,
+        shrinks: func() []tree[N] {
+            var children []tree[N]
+            for _, candidate := range shrinkInt(dest, x) {
+                children = append(children, intTree(dest, candidate))
+            }
+            return children
+        },
+    }
+}
+
+// shrinkInt returns dest, followed by values successively closer to x.
+func shrinkInt[N constraints.Integer](dest, x N) (candidates []N) {
+    if x == dest {
+        return
+    }
+    candidates = append(candidates, dest)
+    for d := (x - dest) / 2; d != 0; d /= 2 {
+        if c := x - d; c != dest {
+            candidates = append(candidates, c)
+        }
+    }
+    return
+}
+
+// bounds returns the minimum and maximum values of N.
+func bounds[N constraints.Integer]() (N, N) {
+    var zero N
+    bits := unsafe.Sizeof(zero) * 8
+    if zero-1 > 0 {
+        return 0, ^zero
+    }
+    max := N(uint64(1)<<(bits-1) - 1)
+    return -max - 1, max
+}
+
+// Int creates a generator of integers of type N, scaled by size and mixed with
+// edge cases such as the minimum and maximum values of N. Values shrink toward
+// zero.
+func Int[N constraints.Integer]() *Gen[N] {
+    min, max := bounds[N]()
+    signed := min < 0
+    edges := []N{0, 1, min, max}
+    if signed {
+        edges = append(edges, N(0)-1)
+    }
+    return &Gen[N]{
+        sample: func(r *rand.Rand, size int) tree[N] {
+            var n N
+            switch {
+            case r.IntN(edgeChance) == 0:
+                n = edges[r.IntN(len(edges))]
+            case signed:
+                n = N(r.Int64N(2*int64(size)+1) - int64(size))
+            default:
+                n = N(r.Uint64N(uint64(size) + 1))
+            }
+            return intTree(0, n)
+        },
+    }
+}
+
+// IntRange creates a generator of integers of type N between min and max
+// inclusive. Values shrink toward zero if it is within range, otherwise toward
+// the bound closest to zero.
+func IntRange[N constraints.Integer](min, max N) *Gen[N] {
+    if min > max {
+        panic(fmt.Sprintf("prop: IntRange min %v greater than max %v", min, max))
+    }
+    dest := N(0)
+    switch {
+    case min > 0:
+        dest = min
+    case max < 0:
+        dest = max
+    }
+    return &Gen[N]{
+        sample: func(r *rand.Rand, _ int) tree[N] {
+            span := uint64(max) - uint64(min)
+            var offset uint64
+            if span == math.MaxUint64 {
+                offset = r.Uint64()
+            } else {
+                offset = r.Uint64N(span + 1)
+            }
+            return intTree(dest, min+N(offset))
+        },
+    }
+}
+
+func floatTree[F constraints.Float](x F) tree[F] {
+    return tree[F]{
+        value: x,
+        shrinks: func() []tree[F] {
+            var children []tree[F]
+            for _, candidate := range shrinkFloat(x) {
+                children = append(children, floatTree(candidate))
+            }
+            return children
+        },
+    }
+}
+
+// shrinkFloat returns zero, the integral part of x and half of x.
+func shrinkFloat[F constraints.Float](x F) (candidates []F) {
+    if x == 0 || math.IsNaN(float64(x)) {
+        return
+    }
+    candidates = append(candidates, 0)
+    if t := F(math.Trunc(float64(x))); t != x && t != 0 {
+        candidates = append(candidates, t)
+    }
+    if h := x / 2; h != x && h != 0 {
+        candidates = append(candidates, h)
+    }
+    return
+}
+
+// Float creates a generator of finite floating point numbers of type F, scaled
+// by size and mixed with edge cases. Values shrink toward zero.
+func Float[F constraints.Float]() *Gen[F] {
+    var zero F
+    max, smallest := float64(math.MaxFloat64), float64(math.SmallestNonzeroFloat64)
+    if unsafe.Sizeof(zero) == 4 {
+        max, smallest = math.MaxFloat32, math.SmallestNonzeroFloat32
+    }
+    edges := []F{0, 1, -1, F(max), F(-max), F(smallest), F(-smallest)}
+    return &Gen[F]{
+        sample: func(r *rand.Rand, size int) tree[F] {
+            var f F
+            if r.IntN(edgeChance) == 0 {
+                f = edges[r.IntN(len(edges))]
+            } else {
+                f = F(r.NormFloat64() * float64(size))
+            }
+            return floatTree(f)
+        },
+    }
+}
+
+// Bool creates a generator of booleans. Values shrink toward false.
+func Bool() *Gen[bool] {
+    return &Gen[bool]{
+        sample: func(r *rand.Rand, _ int) tree[bool] {
+            b := r.IntN(2) == 1
+            if !b {
+                return leaf(b)
+            }
+            return tree[bool]{
+                value: true,
+                shrinks: func() []tree[bool] {
+                    return []tree[bool]{leaf(false)}
+                },
+            }
+        },
+    }
+}
+
+// runeGen creates a generator of mostly printable ASCII runes, with occasional
+// other code points. Runes shrink toward 'a'.
+func runeGen() *Gen[rune] {
+    return &Gen[rune]{
+        sample: func(r *rand.Rand, _ int) tree[rune] {
+            if r.IntN(5) == 0 {
+                return intTree('a', 0xA0+r.Int32N(0xD7FF-0xA0))
+            }
+            return intTree('a', ' '+r.Int32N('~'-' '+1))
+        },
+    }
+}
+
+// String creates a generator of strings of type S with a length of at most
+// size. Strings shrink by dropping characters and by simplifying the
+// remaining characters toward 'a'.
+func String[S ~string]() *Gen[S] {
+    return Map(SliceOf(runeGen()), func(runes []rune) S {
+        return S(runes)
+    })
+}
+
+func sliceTree[A any](elems []tree[A]) tree[[]A] {
+    value := make([]A, len(elems))
+    for i, elem := range elems {
+        value[i] = elem.value
+    }
+    return tree[[]A]{
+        value: value,
+        shrinks: func() []tree[[]A] {
+            var children []tree[[]A]
+            n := len(elems)
+            if n == 0 {
+                return nil
+            }
+            children = append(children, sliceTree[A](nil))
+            if n > 1 {
+                children = append(children, sliceTree(elems[:n/2]), sliceTree(elems[n/2:]))
+            }
+            for i := 0; i < n; i++ {
+                children = append(children, sliceTree(remove(elems, i)))
+            }
+            for i := 0; i < n; i++ {
+                for _, shrunk := range elems[i].shrinks() {
+                    children = append(children, sliceTree(replace(elems, i, shrunk)))
+                }
+            }
+            return children
+        },
+    }
+}
+
+func remove[A any](s []A, i int) []A {
+    result := make([]A, 0, len(s)-1)
+    result = append(result, s[:i]...)
+    return append(result, s[i+1:]...)
+}
+
+func replace[A any](s []A, i int, a A) []A {
+    result := make([]A, len(s))
+    copy(result, s)
+    result[i] = a
+    return result
+}
+
+// SliceOf creates a generator of slices with a length of at most size, with
+// elements produced by elem. Slices shrink by dropping elements and by
+// shrinking individual elements.
+func SliceOf[A any](elem *Gen[A]) *Gen[[]A] {
+    return &Gen[[]A]{
+        sample: func(r *rand.Rand, size int) tree[[]A] {
+            elems := make([]tree[A], r.IntN(size+1))
+            for i := range elems {
+                elems[i] = elem.sample(r, size)
+            }
+            return sliceTree(elems)
+        },
+    }
+}
+
+type entry[K comparable, V any] struct {
+    key   K
+    value tree[V]
+}
+
+func mapTreeOf[K comparable, V any](entries []entry[K, V]) tree[map[K]V] {
+    value := make(map[K]V, len(entries))
+    for _, e := range entries {
+        value[e.key] = e.value.value
+    }
+    return tree[map[K]V]{
+        value: value,
+        shrinks: func() []tree[map[K]V] {
+            var children []tree[map[K]V]
+            for i := range entries {
+                children = append(children, mapTreeOf(remove(entries, i)))
+            }
+            for i, e := range entries {
+                for _, shrunk := range e.value.shrinks() {
+                    children = append(children, mapTreeOf(replace(entries, i, entry[K, V]{e.key, shrunk})))
+                }
+            }
+            return children
+        },
+    }
+}
+
+// MapOf creates a generator of maps with at most size entries, with keys and
+// values produced by keys and values. Maps shrink by dropping entries and by
+// shrinking individual values.
+func MapOf[K comparable, V any](keys *Gen[K], values *Gen[V]) *Gen[map[K]V] {
+    return &Gen[map[K]V]{
+        sample: func(r *rand.Rand, size int) tree[map[K]V] {
+            n := r.IntN(size + 1)
+            seen := make(map[K]bool, n)
+            var entries []entry[K, V]
+            for i := 0; i < n; i++ {
+                key := keys.sample(r, size).value
+                if seen[key] {
+                    continue
+                }
+                seen[key] = true
+                entries = append(entries, entry[K, V]{key, values.sample(r, size)})
+            }
+            return mapTreeOf(entries)
+        },
+    }
+}
+
+// FieldGen generates the value of one field of a struct; see Field.
+type FieldGen struct {
+    name   string
+    typ    reflect.Type
+    sample func(r *rand.Rand, size int) tree[any]
+}
+
+// Field creates a FieldGen setting the exported struct field name to values
+// produced by g.
+func Field[A any](name string, g *Gen[A]) FieldGen {
+    return FieldGen{
+        name: name,
+        typ:  reflect.TypeFor[A](),
+        sample: func(r *rand.Rand, size int) tree[any] {
+            return mapTree(g.sample(r, size), func(a A) any { return a })
+        },
+    }
+}
+
+func structTree[S any](fields []FieldGen, values []tree[any]) tree[S] {
+    var s S
+    rv := reflect.ValueOf(&s).Elem()
+    for i, field := range fields {
+        v := reflect.New(field.typ).Elem()
+        if values[i].value != nil {
+            v.Set(reflect.ValueOf(values[i].value))
+        }
+        rv.FieldByName(field.name).Set(v)
+    }
+    return tree[S]{
+        value: s,
+        shrinks: func() []tree[S] {
+            var children []tree[S]
+            for i := range values {
+                for _, shrunk := range values[i].shrinks() {
+                    children = append(children, structTree[S](fields, replace(values, i, shrunk)))
+                }
+            }
+            return children
+        },
+    }
+}
+
+// Struct creates a generator of structs of type S, with each field named by
+// fields set to generated values. Other fields are left as their zero value.
+// Structs shrink by shrinking individual fields. Struct panics if a field does
+// not exist, is unexported, or is not assignable from its generator.
+func Struct[S any](fields ...FieldGen) *Gen[S] {
+    typ := reflect.TypeFor[S]()
+    if typ.Kind() != reflect.Struct {
+        panic(fmt.Sprintf("prop: Struct requires a struct type, got %s", typ))
+    }
+    for _, field := range fields {
+        f, ok := typ.FieldByName(field.name)
+        switch {
+        case !ok:
+            panic(fmt.Sprintf("prop: struct %s has no field %s", typ, field.name))
+        case !f.IsExported():
+            panic(fmt.Sprintf("prop: field %s of struct %s is unexported", field.name, typ))
+        case !field.typ.AssignableTo(f.Type):
+            panic(fmt.Sprintf("prop: cannot assign %s to field %s of type %s", field.typ, field.name, f.Type))
+        }
+    }
+    return &Gen[S]{
+        sample: func(r *rand.Rand, size int) tree[S] {
+            values := make([]tree[any], len(fields))
+            for i, field := range fields {
+                values[i] = field.sample(r, size)
+            }
+            return structTree[S](fields, values)
+        },
+    }
+}
@@ -0,0 +1,110 @@
+package prop
+
+import (
+    "fmt"
+    "math/rand/v2"
+    "strings"
+    "testing"
+
+    "github.com/ninepeach/go-test/must"
+)
+
+type capture struct {
+    failure string
+}
+
+func (c *capture) Helper() {}
+
+func (c *capture) Fatalf(msg string, args ...any) {
+    c.failure = fmt.Sprintf(msg, args...)
+}
+
+func (c *capture) expect(t *testing.T, subs ...string) {
+    t.Helper()
+    if c.failure == "" {
+        t.Fatal("expected property to fail; it did not")
+    }
+    for _, sub := range subs {
+        if !strings.Contains(c.failure, sub) {
+            t.Fatalf("expected %q in output, got %q", sub, c.failure)
+        }
+    }
+}
+
+type Point struct {
+    X, Y  int
+    Label string
+}
+
+func TestCheck_pass(t *testing.T) {
+    Check(t, Int[int8](), func(t must.T, n int8) {
+        must.True(t, n <= 127 && n >= -128)
+    })
+    Check(t, Float[float32](), func(t must.T, f float32) {
+        must.EqOp(t, f, f)
+    })
+    Check(t, MapOf(String[string](), Bool()), func(t must.T, m map[string]bool) {
+        must.MapLen(t, len(m), m)
+    })
+}
+
+func TestCheck_shrinkInt(t *testing.T) {
+    tc := new(capture)
+    Check(tc, IntRange(0, 10000), func(t must.T, n int) {
+        must.True(t, n < 500)
+    }, Seed(1))
+    tc.expect(t, "counterexample: 500\n", "seed: 1", "expected condition to be true", "prop_test.go:")
+}
+
+func TestCheck_shrinkSlice(t *testing.T) {
+    tc := new(capture)
+    Check(tc, SliceOf(IntRange(0, 100)), func(t must.T, s []int) {
+        for _, n := range s {
+            must.True(t, n < 10)
+        }
+    }, Seed(2))
+    tc.expect(t, "counterexample: []int{10}")
+}
+
+func TestCheck_shrinkString(t *testing.T) {
+    tc := new(capture)
+    Check(tc, String[string](), func(t must.T, s string) {
+        must.True(t, len(s) < 3)
+    }, Seed(3))
+    tc.expect(t, `counterexample: "aaa"`)
+}
+
+func TestCheck_shrinkStruct(t *testing.T) {
+    gen := Struct[Point](
+        Field("X", IntRange(-50, 50)),
+        Field("Label", Elements("a", "b", "c")),
+    )
+    tc := new(capture)
+    Check(tc, gen, func(t must.T, p Point) {
+        must.False(t, p.X > 5 && p.Label == "c")
+    }, Seed(4))
+    tc.expect(t, `counterexample: prop.Point{X: 6, Y: 0, Label: "c"}`)
+}
+
+func TestCheck_combinators(t *testing.T) {
+    even := Map(Int[int](), func(n int) int { return n * 2 })
+    small := Filter(Int[int](), func(n int) bool { return n > -10 && n < 10 })
+    Check(t, OneOf(even, small, Just(42)), func(t must.T, n int) {
+        must.True(t, n%2 == 0 || (n > -10 && n < 10))
+    })
+}
+
+func TestCheck_panic(t *testing.T) {
+    tc := new(capture)
+    Check(tc, SliceOf(Int[int]()), func(t must.T, s []int) {
+        _ = s[0]
+    }, Seed(5))
+    tc.expect(t, "property panicked", "counterexample: []int{}")
+}
+
+func TestGen_seed(t *testing.T) {
+    gen := SliceOf(String[string]())
+    a := gen.Generate(rand.New(rand.NewPCG(7, 7)), 50)
+    b := gen.Generate(rand.New(rand.NewPCG(7, 7)), 50)
+    must.Eq(t, a, b)
+}
@@ -0,0 +1,98 @@
+package skip
+
+import (
+    "os"
+    "os/exec"
+    "runtime"
+    "slices"
+    "testing"
+
+    "github.com/ninepeach/go-test/internal/race"
+)
+
+// T is the minimal set of functions to be implemented by any testing framework
+// compatible with the skip package.
+type T interface {
+    Helper()
+    Skipf(string, ...any)
+}
+
+// skipf skips the test, giving reason in a consistent format.
+func skipf(t T, reason string, args ...any) {
+    t.Helper()
+    t.Skipf("skipping test: "+reason, args...)
+}
+
+// NotLinux skips the test if the operating system is not Linux.
+func NotLinux(t T) {
+    t.Helper()
+    if runtime.GOOS != "linux" {
+        skipf(t, "operating system is %s, not linux", runtime.GOOS)
+    }
+}
+
+// CommandUnavailable skips the test if command cannot be found in PATH.
+func CommandUnavailable(t T, command string) {
+    t.Helper()
+    if _, err := exec.LookPath(command); err != nil {
+        skipf(t, "command %q is unavailable", command)
+    }
+}
+
+// NotRoot skips the test if the user is not root.
+func NotRoot(t T) {
+    t.Helper()
+    if uid := os.Geteuid(); uid != 0 {
+        skipf(t, "user is not root (euid %d)", uid)
+    }
+}
+
+// UserIsRoot skips the test if the user is root.
+func UserIsRoot(t T) {
+    t.Helper()
+    if os.Geteuid() == 0 {
+        skipf(t, "user is root")
+    }
+}
+
+// EnvironmentVariableSet skips the test if the environment variable name is
+// set, even if it is empty.
+func EnvironmentVariableSet(t T, name string) {
+    t.Helper()
+    if _, set := os.LookupEnv(name); set {
+        skipf(t, "environment variable %s is set", name)
+    }
+}
+
+// Short skips the test if tests are running in short mode.
+func Short(t T) {
+    t.Helper()
+    if testing.Short() {
+        skipf(t, "running in short mode")
+    }
+}
+
+// RaceEnabled skips the test if the race detector is enabled.
+func RaceEnabled(t T) {
+    t.Helper()
+    if race.Enabled {
+        skipf(t, "race detector is enabled")
+    }
+}
+
+// CPUsLessThan skips the test if fewer than n logical CPUs are available.
+func CPUsLessThan(t T, n int) {
+    t.Helper()
+    if cpus := runtime.NumCPU(); cpus < n {
+        skipf(t, "%d cpu(s) available, need %d", cpus, n)
+    }
+}
+
+// Architecture skips the test if the architecture is one of archs, given as
+// values of GOARCH.
+func Architecture(t T, archs ...string) {
+    t.Helper()
+    if slices.Contains(archs, runtime.GOARCH) {
+        skipf(t, "architecture is %s", runtime.GOARCH)
+    }
+}
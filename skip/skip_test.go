@@ -0,0 +1,80 @@
+package skip
+
+import (
+    "fmt"
+    "os"
+    "runtime"
+    "strings"
+    "testing"
+
+    "github.com/ninepeach/go-test/internal/race"
+)
+
+type recorder struct {
+    helper bool
+    reason string
+}
+
+func (r *recorder) Helper() {
+    r.helper = true
+}
+
+func (r *recorder) Skipf(msg string, args ...any) {
+    r.reason = fmt.Sprintf(msg, args...)
+}
+
+func check(t *testing.T, fn func(T), skipped bool, reason string) {
+    t.Helper()
+    r := new(recorder)
+    fn(r)
+    if !r.helper {
+        t.Fatal("should be marked as helper")
+    }
+    switch {
+    case skipped && r.reason == "":
+        t.Fatal("expected test to be skipped; it was not")
+    case !skipped && r.reason != "":
+        t.Fatalf("expected test not to be skipped; got %q", r.reason)
+    case skipped && (!strings.HasPrefix(r.reason, "skipping test: ") || !strings.Contains(r.reason, reason)):
+        t.Fatalf("expected reason %q, got %q", reason, r.reason)
+    }
+}
+
+func TestNotLinux(t *testing.T) {
+    check(t, NotLinux, runtime.GOOS != "linux", "not linux")
+}
+
+func TestCommandUnavailable(t *testing.T) {
+    check(t, func(t T) { CommandUnavailable(t, "go") }, false, "")
+    check(t, func(t T) { CommandUnavailable(t, "no-such-command-exists") }, true, `command "no-such-command-exists" is unavailable`)
+}
+
+func TestRoot(t *testing.T) {
+    root := os.Geteuid() == 0
+    check(t, NotRoot, !root, "user is not root")
+    check(t, UserIsRoot, root, "user is root")
+}
+
+func TestEnvironmentVariableSet(t *testing.T) {
+    t.Setenv("SKIP_TEST_VARIABLE", "")
+    check(t, func(t T) { EnvironmentVariableSet(t, "SKIP_TEST_VARIABLE") }, true, "environment variable SKIP_TEST_VARIABLE is set")
+    check(t, func(t T) { EnvironmentVariableSet(t, "SKIP_TEST_UNSET_VARIABLE") }, false, "")
+}
+
+func TestShort(t *testing.T) {
+    check(t, Short, testing.Short(), "running in short mode")
+}
+
+func TestRaceEnabled(t *testing.T) {
+    check(t, RaceEnabled, race.Enabled, "race detector is enabled")
+}
+
+func TestCPUsLessThan(t *testing.T) {
+    check(t, func(t T) { CPUsLessThan(t, 1) }, false, "")
+    check(t, func(t T) { CPUsLessThan(t, runtime.NumCPU()+1) }, true, "cpu(s) available, need")
+}
+
+func TestArchitecture(t *testing.T) {
+    check(t, func(t T) { Architecture(t, runtime.GOARCH) }, true, "architecture is "+runtime.GOARCH)
+    check(t, func(t T) { Architecture(t, "not-an-arch") }, false, "")
+}
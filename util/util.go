@@ -0,0 +1,189 @@
+package util
+
+import (
+    "fmt"
+    "io"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// T is the subset of testing.TB needed to create temporary files which are
+// removed when the test completes.
+type T interface {
+    Helper()
+    Fatalf(string, ...any)
+    Cleanup(func())
+    TempDir() string
+}
+
+// TempFileSettings configures a file created by TempFile.
+type TempFileSettings struct {
+    data    []byte
+    mode    *fs.FileMode
+    pattern string
+    dir     string
+}
+
+// TempFileSetting modifies the TempFileSettings configuration.
+type TempFileSetting func(*TempFileSettings)
+
+// StringData sets the content of the file to data.
+func StringData(data string) TempFileSetting {
+    return func(s *TempFileSettings) {
+        s.data = []byte(data)
+    }
+}
+
+// ByteData sets the content of the file to data.
+func ByteData(data []byte) TempFileSetting {
+    return func(s *TempFileSettings) {
+        s.data = data
+    }
+}
+
+// Mode sets the permissions of the file, regardless of the umask.
+func Mode(mode fs.FileMode) TempFileSetting {
+    return func(s *TempFileSettings) {
+        s.mode = &mode
+    }
+}
+
+// Pattern sets the pattern of the file name as used by os.CreateTemp, where
+// the last "*" is replaced by a random string.
+func Pattern(pattern string) TempFileSetting {
+    return func(s *TempFileSettings) {
+        s.pattern = pattern
+    }
+}
+
+// Dir sets the directory the file is created in. By default it is created in
+// a directory returned by t.TempDir.
+func Dir(dir string) TempFileSetting {
+    return func(s *TempFileSettings) {
+        s.dir = dir
+    }
+}
+
+// TempFile creates a temporary file, returning its path. The file is removed
+// when the test completes.
+func TempFile(t T, settings ...TempFileSetting) string {
+    t.Helper()
+    s := new(TempFileSettings)
+    for _, setting := range settings {
+        setting(s)
+    }
+    dir := s.dir
+    if dir == "" {
+        dir = t.TempDir()
+    }
+
+    f, err := os.CreateTemp(dir, s.pattern)
+    if err != nil {
+        t.Fatalf("failed to create temp file: %v", err)
+        return ""
+    }
+    name := f.Name()
+    t.Cleanup(func() {
+        _ = os.Remove(name)
+    })
+
+    _, err = f.Write(s.data)
+    if closeErr := f.Close(); err == nil {
+        err = closeErr
+    }
+    if err != nil {
+        t.Fatalf("failed to write temp file %q: %v", name, err)
+        return name
+    }
+    if s.mode != nil {
+        if err := os.Chmod(name, *s.mode); err != nil {
+            t.Fatalf("failed to set mode of temp file %q: %v", name, err)
+        }
+    }
+    return name
+}
+
+// TempTree creates a temporary directory laid out with files, which maps slash
+// separated paths to file content, returning the path of the directory. Paths
+// ending in a slash create empty directories. The directory is removed when
+// the test completes.
+func TempTree(t T, files map[string]string) string {
+    t.Helper()
+    root := t.TempDir()
+    for name, content := range files {
+        if !fs.ValidPath(strings.TrimSuffix(name, "/")) {
+            t.Fatalf("invalid path %q in temp tree", name)
+            return root
+        }
+        path := filepath.Join(root, filepath.FromSlash(name))
+        if strings.HasSuffix(name, "/") {
+            if err := os.MkdirAll(path, 0o755); err != nil {
+                t.Fatalf("failed to create directory in temp tree: %v", err)
+                return root
+            }
+            continue
+        }
+        if err := writeFile(path, strings.NewReader(content), 0o644); err != nil {
+            t.Fatalf("failed to create file in temp tree: %v", err)
+            return root
+        }
+    }
+    return root
+}
+
+// TempTreeFromFS copies system into a temporary directory, returning the path
+// of the directory. It is typically used to get a writable copy of testdata.
+// Permissions are preserved, except that the owner may always write. The
+// directory is removed when the test completes.
+func TempTreeFromFS(t T, system fs.FS) string {
+    t.Helper()
+    root := t.TempDir()
+    err := fs.WalkDir(system, ".", func(name string, d fs.DirEntry, err error) error {
+        if err != nil {
+            return err
+        }
+        path := filepath.Join(root, filepath.FromSlash(name))
+        if d.IsDir() {
+            return os.MkdirAll(path, 0o755)
+        }
+        if !d.Type().IsRegular() {
+            return fmt.Errorf("cannot copy %q: unsupported file type %s", name, d.Type())
+        }
+        info, err := d.Info()
+        if err != nil {
+            return err
+        }
+        f, err := system.Open(name)
+        if err != nil {
+            return err
+        }
+        defer f.Close()
+        return writeFile(path, f, info.Mode().Perm()|0o200)
+    })
+    if err != nil {
+        t.Fatalf("failed to copy file system to temp tree: %v", err)
+    }
+    return root
+}
+
+// writeFile writes the content of r to path with mode, creating parent
+// directories as needed.
+func writeFile(path string, r io.Reader, mode fs.FileMode) error {
+    if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+        return err
+    }
+    f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+    if err != nil {
+        return err
+    }
+    _, err = io.Copy(f, r)
+    if closeErr := f.Close(); err == nil {
+        err = closeErr
+    }
+    if err != nil {
+        return err
+    }
+    return os.Chmod(path, mode)
+}
@@ -0,0 +1,77 @@
+package util
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "testing/fstest"
+
+    "github.com/ninepeach/go-test/must"
+    "github.com/ninepeach/go-test/musttest"
+)
+
+func TestTempFile(t *testing.T) {
+    t.Run("defaults", func(t *testing.T) {
+        name := TempFile(t)
+        must.FileEq(t, name, "")
+    })
+
+    t.Run("settings", func(t *testing.T) {
+        dir := t.TempDir()
+        name := TempFile(t, StringData("hello"), Mode(0o600), Pattern("config-*.yaml"), Dir(dir))
+        must.Eq(t, dir, filepath.Dir(name))
+        must.True(t, strings.HasPrefix(filepath.Base(name), "config-"))
+        must.True(t, strings.HasSuffix(name, ".yaml"))
+        must.FileEq(t, name, "hello")
+        must.FileMode(t, name, 0o600)
+    })
+
+    t.Run("cleanup", func(t *testing.T) {
+        rec := musttest.New(t)
+        name := TempFile(rec, ByteData([]byte{1, 2, 3}))
+        must.FileSize(t, name, 3)
+        rec.RunCleanups()
+        must.FileNotExists(t, name)
+        must.SliceEmpty(t, rec.Failures())
+    })
+
+    t.Run("missing dir", func(t *testing.T) {
+        rec := musttest.New(t)
+        TempFile(rec, Dir(filepath.Join(t.TempDir(), "missing")))
+        rec.ExpectFailure("failed to create temp file")
+    })
+}
+
+func TestTempTree(t *testing.T) {
+    t.Run("layout", func(t *testing.T) {
+        root := TempTree(t, map[string]string{
+            "go.mod":          "module example\n",
+            "cmd/app/main.go": "package main\n",
+            "empty/":          "",
+        })
+        must.FileEq(t, filepath.Join(root, "go.mod"), "module example\n")
+        must.FileEq(t, filepath.Join(root, "cmd", "app", "main.go"), "package main\n")
+        must.DirExists(t, filepath.Join(root, "empty"))
+    })
+
+    t.Run("invalid", func(t *testing.T) {
+        rec := musttest.New(t)
+        TempTree(rec, map[string]string{"../escape": "x"})
+        rec.ExpectFailure(`invalid path "../escape" in temp tree`)
+    })
+}
+
+func TestTempTreeFromFS(t *testing.T) {
+    system := fstest.MapFS{
+        "golden/a.txt":  {Data: []byte("a"), Mode: 0o444},
+        "golden/b/c.sh": {Data: []byte("c"), Mode: 0o755},
+    }
+    root := TempTreeFromFS(t, system)
+    must.FileMode(t, filepath.Join(root, "golden", "a.txt"), 0o644)
+    must.FileMode(t, filepath.Join(root, "golden", "b", "c.sh"), 0o755)
+    must.DirTreeEq(t, system, os.DirFS(root), must.IgnoreModes())
+
+    // the copy is writable
+    must.NoError(t, os.WriteFile(filepath.Join(root, "golden", "a.txt"), []byte(strings.Repeat("a", 2)), 0o644))
+}